@@ -1,13 +1,190 @@
 package main
 
-import "github.com/saniapro/tetris/pkg/game"
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
-// main initializes the terminal, creates a new game, and runs the game loop.
-// Ensures terminal is properly restored on exit.
+	"github.com/saniapro/tetris/pkg/game"
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// main parses the command line, initializes the terminal, creates a new game,
+// and runs the game loop. Ensures terminal is properly restored on exit.
+// "tetris replay <file>" plays back a recorded game instead.
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		replay(os.Args[2:])
+		return
+	}
+
+	cfg := game.DefaultConfig()
+	configPath := flag.String("config", "", "config file (default: $XDG_CONFIG_HOME/tetris/config.json)")
+	flag.StringVar(&cfg.Rotation, "rotation", cfg.Rotation, "rotation system: "+strings.Join(tetris.RotationSystems, ", "))
+	flag.StringVar(&cfg.Scoring, "scoring", cfg.Scoring, "scoring rule: "+strings.Join(tetris.ScoringRules, ", "))
+	flag.StringVar(&cfg.Randomizer, "randomizer", cfg.Randomizer, "piece randomizer: "+strings.Join(tetris.Randomizers, ", "))
+	seed := flag.Int64("seed", 0, "seed for the piece sequence, 0 picks one from the clock")
+	flag.StringVar(&cfg.Gravity, "gravity", cfg.Gravity, "gravity curve: "+strings.Join(tetris.GravityCurves, ", "))
+	flag.DurationVar((*time.Duration)(&cfg.LockDelay), "lock-delay", time.Duration(cfg.LockDelay), "time a grounded piece may move before it locks")
+	flag.StringVar(&cfg.LockReset, "lock-reset", cfg.LockReset, "lock delay reset mode: move, step, none")
+	flag.DurationVar((*time.Duration)(&cfg.DAS), "das", time.Duration(cfg.DAS), "delayed auto shift: how long a move key is held before it repeats")
+	flag.DurationVar((*time.Duration)(&cfg.ARR), "arr", time.Duration(cfg.ARR), "auto repeat rate: time between repeated moves, 0 shifts to the wall")
+	flag.DurationVar((*time.Duration)(&cfg.SoftDrop), "soft-drop", time.Duration(cfg.SoftDrop), "time per row while soft drop is held, 0 drops to the floor")
+	flag.DurationVar((*time.Duration)(&cfg.ARE), "are", time.Duration(cfg.ARE), "entry delay between a lock and the next piece")
+	flag.DurationVar((*time.Duration)(&cfg.LineClearDelay), "line-clear-delay", time.Duration(cfg.LineClearDelay), "time cleared rows animate before they collapse")
+	flag.IntVar(&cfg.BoardWidth, "width", cfg.BoardWidth, fmt.Sprintf("board width in cells (%d-%d)", tetris.MinBoardWidth, tetris.MaxBoardWidth))
+	flag.IntVar(&cfg.BoardHeight, "height", cfg.BoardHeight, fmt.Sprintf("board height in cells (%d-%d)", tetris.MinBoardHeight, tetris.MaxBoardHeight))
+	flag.IntVar(&cfg.Preview, "preview", cfg.Preview, fmt.Sprintf("number of next pieces shown (%d-%d)", game.MinPreview, game.MaxPreview))
+	flag.BoolVar(&cfg.PeekRow, "peek", cfg.PeekRow, "show blocks in the row just above the board on its top border")
+	flag.StringVar(&cfg.Theme, "theme", cfg.Theme, "color theme: "+strings.Join(game.ThemeNames(), ", "))
+	flag.StringVar(&cfg.KeyPreset, "keys", cfg.KeyPreset, "key binding preset: "+strings.Join(game.KeyPresetNames(), ", "))
+	record := flag.String("record", "", "replay file written when the game ends (default: a new file in the data directory)")
+	savePath := flag.String("save", "", "file a quit game is saved to and resumed from (default: save.json in the data directory)")
+	resume := flag.Bool("resume", false, "continue the game saved when you last quit; its rules override the flags")
+	flag.Parse()
+
+	var err error
+	if *configPath == "" {
+		if *configPath, err = game.DefaultConfigPath(); err != nil {
+			fail(err)
+		}
+	}
+	opts, err := loadOptions(&cfg, *configPath)
+	if err != nil {
+		fail(err)
+	}
+	opts.Seed = *seed
+
+	if *savePath == "" {
+		if *savePath, err = game.DefaultSavePath(); err != nil {
+			fail(err)
+		}
+	}
+	var gs *game.GameState
+	if *resume {
+		gs, err = game.Resume(*savePath, opts)
+	} else {
+		gs, err = game.Init(opts)
+	}
+	if err != nil {
+		fail(err)
+	}
+
+	scores, err := game.DefaultHighScorePath()
+	if err != nil {
+		fail(err)
+	}
+	gs, err = play(gs, opts, scores)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "high scores:", err)
+	}
+	if gs.KeysChanged {
+		if err := game.SaveKeys(*configPath, cfg.KeyPreset, gs.Bindings); err != nil {
+			fail(err)
+		}
+	}
+
+	if gs.Quit {
+		if err := gs.Save(*savePath); err != nil {
+			fail(err)
+		}
+		fmt.Println("game saved to", *savePath, "- continue with -resume")
+		return
+	}
+	if *resume {
+		// the saved game is over now, so it cannot be resumed again
+		if err := os.Remove(*savePath); err != nil {
+			fail(err)
+		}
+	}
+	if gs.Replay == nil {
+		return
+	}
+	if *record == "" {
+		if *record, err = game.DefaultReplayPath(); err != nil {
+			fail(err)
+		}
+	}
+	if err := gs.SaveReplay(*record); err != nil {
+		fail(err)
+	}
+	fmt.Println("replay saved to", *record)
+}
+
+// loadOptions layers the config file under the command line: the file at path
+// is read into cfg, then the flags are parsed again so that any flag given
+// explicitly wins.
+func loadOptions(cfg *game.Config, path string) (game.Options, error) {
+	if err := cfg.Load(path); err != nil {
+		return game.Options{}, err
+	}
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return game.Options{}, err
+	}
+	return cfg.Options()
+}
+
+// play runs gs in the terminal until the game ends, starting over with opts
+// whenever the player restarts, then records a finished game in the
+// high-score table at scores. Returns the last game played.
+func play(gs *game.GameState, opts game.Options, scores string) (*game.GameState, error) {
 	game.InitTerminal()
 	defer game.RestoreTerminal()
 
-	gs := game.Init()
-	game.Loop(gs)
+	for {
+		game.Loop(gs)
+		if !gs.Restart {
+			break
+		}
+		next, err := game.Init(opts)
+		if err != nil {
+			return gs, err
+		}
+		next.KeysChanged = gs.KeysChanged
+		gs = next
+	}
+	if !gs.GameOver {
+		return gs, nil
+	}
+	return gs, game.HighScoreScreen(gs, scores)
+}
+
+// replay plays back the replay file named in args, drawn with the configured theme.
+func replay(args []string) {
+	if len(args) != 1 {
+		fail(fmt.Errorf("usage: tetris replay <file>"))
+	}
+	rep, err := game.LoadReplay(args[0])
+	if err != nil {
+		fail(err)
+	}
+	cfg := game.DefaultConfig()
+	path, err := game.DefaultConfigPath()
+	if err != nil {
+		fail(err)
+	}
+	if err := cfg.Load(path); err != nil {
+		fail(err)
+	}
+	opts, err := cfg.Options()
+	if err != nil {
+		fail(err)
+	}
+	gs, err := game.InitReplay(rep, opts)
+	if err != nil {
+		fail(err)
+	}
+
+	game.InitTerminal()
+	defer game.RestoreTerminal()
+
+	game.PlayReplay(gs, rep)
+}
+
+// fail reports a command line error and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
 }
@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// Action is something the player can do with a key. Game actions are passed to
+// the engine as inputs; the others control the game itself.
+type Action int
+
+const (
+	ActionMoveLeft Action = iota
+	ActionMoveRight
+	ActionSoftDrop
+	ActionHardDrop
+	ActionRotateCW
+	ActionRotateCCW
+	ActionRotate180
+	ActionHold
+	ActionLevelUp
+	ActionLevelDown
+	ActionPause
+	ActionRestart
+	ActionKeyBindings // Opens the key binding screen
+	ActionQuit
+	NumActions = iota
+)
+
+// actionNames are the names used for actions in the config file.
+var actionNames = [NumActions]string{
+	"move_left", "move_right", "soft_drop", "hard_drop", "rotate_cw", "rotate_ccw", "rotate_180",
+	"hold", "level_up", "level_down", "pause", "restart", "key_bindings", "quit",
+}
+
+// actionLabels describe actions on the key binding screen.
+var actionLabels = [NumActions]string{
+	"Move left", "Move right", "Soft drop", "Hard drop", "Rotate CW", "Rotate CCW", "Rotate 180",
+	"Hold", "Level up", "Level down", "Pause", "Restart", "Key bindings", "Quit",
+}
+
+// actionInputs are the engine inputs of the game actions.
+var actionInputs = map[Action]tetris.Input{
+	ActionMoveLeft:  tetris.InputLeft,
+	ActionMoveRight: tetris.InputRight,
+	ActionSoftDrop:  tetris.InputSoftDrop,
+	ActionHardDrop:  tetris.InputHardDrop,
+	ActionRotateCW:  tetris.InputRotateCW,
+	ActionRotateCCW: tetris.InputRotateCCW,
+	ActionRotate180: tetris.InputRotate180,
+	ActionHold:      tetris.InputHold,
+	ActionLevelUp:   tetris.InputLevelUp,
+	ActionLevelDown: tetris.InputLevelDown,
+}
+
+// String returns the config file name of the action.
+func (a Action) String() string {
+	if a < 0 || a >= NumActions {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// Input returns the engine input triggered by a game action.
+func (a Action) Input() (tetris.Input, bool) {
+	in, ok := actionInputs[a]
+	return in, ok
+}
+
+// ParseAction converts a config file name such as "rotate_cw" to an Action.
+func ParseAction(name string) (Action, error) {
+	for i, n := range actionNames {
+		if n == name {
+			return Action(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown action %q", name)
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Bindings maps every action to the keys that trigger it. Keys are named as in
+// the config file: a single character ("x", "+"), "Space", or a tcell key name
+// such as "Left", "Esc", "Enter" or "F1". Letters are case sensitive.
+type Bindings map[Action][]string
+
+// KeyPresets are the built-in binding tables by name.
+var KeyPresets = map[string]Bindings{
+	"default": {
+		ActionMoveLeft:    {"Left"},
+		ActionMoveRight:   {"Right"},
+		ActionSoftDrop:    {"Down"},
+		ActionHardDrop:    {"Space"},
+		ActionRotateCW:    {"Up", "x"},
+		ActionRotateCCW:   {"z"},
+		ActionRotate180:   {"a"},
+		ActionHold:        {"c"},
+		ActionLevelUp:     {"+"},
+		ActionLevelDown:   {"-"},
+		ActionPause:       {"p"},
+		ActionRestart:     {"r"},
+		ActionKeyBindings: {"F1"},
+		ActionQuit:        {"q", "Esc"},
+	},
+	"wasd": {
+		ActionMoveLeft:    {"a"},
+		ActionMoveRight:   {"d"},
+		ActionSoftDrop:    {"s"},
+		ActionHardDrop:    {"w"},
+		ActionRotateCW:    {"k"},
+		ActionRotateCCW:   {"j"},
+		ActionRotate180:   {"l"},
+		ActionHold:        {"Space"},
+		ActionLevelUp:     {"+"},
+		ActionLevelDown:   {"-"},
+		ActionPause:       {"p"},
+		ActionRestart:     {"r"},
+		ActionKeyBindings: {"F1"},
+		ActionQuit:        {"q", "Esc"},
+	},
+	"vim": {
+		ActionMoveLeft:    {"h"},
+		ActionMoveRight:   {"l"},
+		ActionSoftDrop:    {"j"},
+		ActionHardDrop:    {"k"},
+		ActionRotateCW:    {"f"},
+		ActionRotateCCW:   {"d"},
+		ActionRotate180:   {"s"},
+		ActionHold:        {"a"},
+		ActionLevelUp:     {"+"},
+		ActionLevelDown:   {"-"},
+		ActionPause:       {"p"},
+		ActionRestart:     {"r"},
+		ActionKeyBindings: {"F1"},
+		ActionQuit:        {"q", "Esc"},
+	},
+}
+
+// KeyPresetNames returns the names of the built-in presets, sorted.
+func KeyPresetNames() []string {
+	names := make([]string, 0, len(KeyPresets))
+	for n := range KeyPresets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewBindings returns the preset name with the keys of the actions in
+// overrides replaced. Override keys are action names such as "hard_drop".
+// A key given to an action is taken away from the preset's other actions;
+// giving one key to two actions is an error.
+func NewBindings(preset string, overrides map[string][]string) (Bindings, error) {
+	p, ok := KeyPresets[strings.ToLower(preset)]
+	if !ok {
+		return nil, fmt.Errorf("unknown key preset %q (want one of %s)", preset, strings.Join(KeyPresetNames(), ", "))
+	}
+	b := make(Bindings, NumActions)
+	for a, keys := range p {
+		b[a] = append([]string(nil), keys...)
+	}
+	owner := map[string]Action{}
+	for name, keys := range overrides {
+		a, err := ParseAction(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			if !validKey(k) {
+				return nil, fmt.Errorf("action %s: unknown key %q", name, k)
+			}
+			if o, ok := owner[k]; ok && o != a {
+				return nil, fmt.Errorf("key %q is bound to both %s and %s", k, min(a, o), max(a, o))
+			}
+			owner[k] = a
+		}
+		b[a] = append([]string(nil), keys...)
+	}
+	for k, a := range owner {
+		for other := range b {
+			if other != a {
+				b[other] = removeKey(b[other], k)
+			}
+		}
+	}
+	return b, nil
+}
+
+// Action returns the action bound to the key of e.
+func (b Bindings) Action(e *tcell.EventKey) (Action, bool) {
+	name := keyName(e)
+	for a := Action(0); a < NumActions; a++ {
+		for _, k := range b[a] {
+			if k == name {
+				return a, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Bind makes key the only key of action a and removes it from other actions.
+func (b Bindings) Bind(a Action, key string) {
+	for other, keys := range b {
+		b[other] = removeKey(keys, key)
+	}
+	b[a] = []string{key}
+}
+
+// Overrides returns the bindings that differ from preset, by action name, as
+// stored in the config file.
+func (b Bindings) Overrides(preset string) map[string][]string {
+	p := KeyPresets[preset]
+	out := map[string][]string{}
+	for a := Action(0); a < NumActions; a++ {
+		if strings.Join(b[a], "\x00") != strings.Join(p[a], "\x00") {
+			out[a.String()] = append([]string{}, b[a]...)
+		}
+	}
+	return out
+}
+
+// removeKey returns keys without key.
+func removeKey(keys []string, key string) []string {
+	var out []string
+	for _, k := range keys {
+		if k != key {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// keyName returns the config file name of the key of e.
+func keyName(e *tcell.EventKey) string {
+	if e.Key() == tcell.KeyRune {
+		if e.Rune() == ' ' {
+			return "Space"
+		}
+		return string(e.Rune())
+	}
+	if name, ok := tcell.KeyNames[e.Key()]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key%d", int(e.Key()))
+}
+
+// validKey reports whether name is a key keyName can return.
+func validKey(name string) bool {
+	if name == "Space" || len([]rune(name)) == 1 {
+		return true
+	}
+	for _, n := range tcell.KeyNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
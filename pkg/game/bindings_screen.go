@@ -0,0 +1,58 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// KeyBindingScreen lets the player remap keys while the game waits.
+// Up and Down select an action, Enter captures the next key press as its only
+// key, and Esc returns to the game. Changes set KeysChanged.
+func (gs *GameState) KeyBindingScreen() {
+	sel := Action(0)
+	for {
+		gs.drawKeyBindings(sel, "Up/Down select  Enter rebind  Esc back")
+		e := gs.waitKey()
+		if e == nil {
+			return
+		}
+		switch e.Key() {
+		case tcell.KeyEsc:
+			return
+		case tcell.KeyUp:
+			sel = (sel + NumActions - 1) % NumActions
+		case tcell.KeyDown:
+			sel = (sel + 1) % NumActions
+		case tcell.KeyEnter:
+			gs.drawKeyBindings(sel, "Press a key for "+actionLabels[sel]+"...")
+			if k := gs.waitKey(); k != nil {
+				gs.Bindings.Bind(sel, keyName(k))
+				gs.KeysChanged = true
+			}
+		}
+	}
+}
+
+// drawKeyBindings renders the binding table with the action sel highlighted.
+func (gs *GameState) drawKeyBindings(sel Action, prompt string) {
+	if gs.R == nil {
+		return
+	}
+	gs.R.Clear()
+	x, y := tetris.BoardXOffset, tetris.BoardYOffset
+	gs.R.PutStr(x, y, "KEY BINDINGS")
+	for a := Action(0); a < NumActions; a++ {
+		row := fmt.Sprintf("%-14s %s", actionLabels[a], strings.Join(gs.Bindings[a], ", "))
+		if a == sel {
+			gs.R.PutStrColor(x, y+2+int(a), "> "+row, tcell.ColorYellow)
+		} else {
+			gs.R.PutStr(x, y+2+int(a), "  "+row)
+		}
+	}
+	gs.R.PutStr(x, y+3+int(NumActions), prompt)
+	gs.R.Show()
+}
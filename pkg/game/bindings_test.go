@@ -0,0 +1,43 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNewBindingsOverrides(t *testing.T) {
+	b, err := NewBindings("default", map[string][]string{"rotate_cw": {"Space"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	space := tcell.NewEventKey(tcell.KeyRune, ' ', 0)
+	if a, ok := b.Action(space); !ok || a != ActionRotateCW {
+		t.Errorf("Space triggers %v, want %v", a, ActionRotateCW)
+	}
+	if keys := b[ActionHardDrop]; slices.Contains(keys, "Space") {
+		t.Errorf("hard_drop keeps Space: %q", keys)
+	}
+	if got := b.Overrides("default"); !slices.Equal(got["rotate_cw"], []string{"Space"}) {
+		t.Errorf("Overrides = %q", got)
+	}
+}
+
+func TestNewBindingsRejects(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+	}{
+		{"unknown action", map[string][]string{"spin": {"x"}}},
+		{"unknown key", map[string][]string{"hold": {"Shift"}}},
+		{"key on two actions", map[string][]string{"hold": {"c"}, "hard_drop": {"Space", "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBindings("default", tt.overrides); err == nil {
+				t.Error("NewBindings accepted the overrides")
+			}
+		})
+	}
+}
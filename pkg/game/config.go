@@ -0,0 +1,173 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// Config is the user configuration file. Every field is optional and falls
+// back to DefaultConfig; command line flags override the file.
+type Config struct {
+	Rotation       string              `json:"rotation"`
+	Scoring        string              `json:"scoring"`
+	Gravity        string              `json:"gravity"`
+	Randomizer     string              `json:"randomizer"`
+	LockDelay      Duration            `json:"lock_delay"`
+	LockReset      string              `json:"lock_reset"`
+	DAS            Duration            `json:"das"`
+	ARR            Duration            `json:"arr"`
+	SoftDrop       Duration            `json:"soft_drop"`
+	ARE            Duration            `json:"are"`
+	LineClearDelay Duration            `json:"line_clear_delay"`
+	BoardWidth     int                 `json:"board_width"`
+	BoardHeight    int                 `json:"board_height"`
+	Preview        int                 `json:"preview"`
+	PeekRow        bool                `json:"peek_row"`
+	Theme          string              `json:"theme"`
+	Colors         map[string]string   `json:"colors,omitempty"` // Per-piece and border color overrides
+	KeyPreset      string              `json:"key_preset"`
+	Keys           map[string][]string `json:"keys,omitempty"` // Keys per action name, replacing the preset's
+}
+
+// Duration is a time.Duration written as a string such as "167ms" in the config file.
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON accepts a duration string such as "500ms" or "0".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"167ms\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// DefaultConfig returns the guideline configuration.
+func DefaultConfig() Config {
+	return Config{
+		Rotation:    "srs",
+		Scoring:     "guideline",
+		Gravity:     "guideline",
+		Randomizer:  "7bag",
+		LockDelay:   Duration(500 * time.Millisecond),
+		LockReset:   tetris.LockResetMove.String(),
+		DAS:         Duration(167 * time.Millisecond),
+		ARR:         Duration(33 * time.Millisecond),
+		SoftDrop:    Duration(17 * time.Millisecond),
+		BoardWidth:  tetris.BoardWidth,
+		BoardHeight: tetris.BoardHeight,
+		Preview:     DefaultPreview,
+		Theme:       "default",
+	}
+}
+
+// DefaultConfigPath returns $XDG_CONFIG_HOME/tetris/config.json, or
+// ~/.config/tetris/config.json when the variable is unset.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, appName, "config.json"), nil
+}
+
+// Load reads the config file at path on top of c, so fields missing from
+// the file keep their value. A missing file leaves c unchanged.
+func (c *Config) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Options resolves the named rules, timings and theme into game options.
+func (c Config) Options() (Options, error) {
+	opts := DefaultOptions()
+	var err error
+	if opts.Rotation, err = tetris.NewRotationSystem(c.Rotation); err != nil {
+		return Options{}, err
+	}
+	if opts.Scoring, err = tetris.NewScoringRule(c.Scoring); err != nil {
+		return Options{}, err
+	}
+	if opts.Gravity, err = tetris.NewGravityCurve(c.Gravity); err != nil {
+		return Options{}, err
+	}
+	if opts.LockReset, err = tetris.ParseLockReset(c.LockReset); err != nil {
+		return Options{}, err
+	}
+	if err := tetris.CheckBoardSize(c.BoardWidth, c.BoardHeight); err != nil {
+		return Options{}, err
+	}
+	if opts.Theme, err = NewTheme(c.Theme, c.Colors); err != nil {
+		return Options{}, err
+	}
+	if opts.Bindings, err = NewBindings(c.KeyPreset, c.Keys); err != nil {
+		return Options{}, err
+	}
+	opts.Randomizer = c.Randomizer
+	opts.LockDelay = tetris.Frames(time.Duration(c.LockDelay))
+	opts.DAS = tetris.Frames(time.Duration(c.DAS))
+	opts.ARR = tetris.Frames(time.Duration(c.ARR))
+	opts.SoftDropRate = tetris.Frames(time.Duration(c.SoftDrop))
+	opts.ARE = tetris.Frames(time.Duration(c.ARE))
+	opts.LineClear = tetris.Frames(time.Duration(c.LineClearDelay))
+	opts.Width, opts.Height = c.BoardWidth, c.BoardHeight
+	opts.Preview = c.Preview
+	opts.PeekRow = c.PeekRow
+	return opts, nil
+}
+
+// SaveKeys stores preset and the keys of b that differ from it in the config
+// file at path, leaving every other setting in the file untouched.
+func SaveKeys(path, preset string, b Bindings) error {
+	file := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	keys, err := json.Marshal(b.Overrides(preset))
+	if err != nil {
+		return err
+	}
+	file["keys"] = keys
+	if file["key_preset"], err = json.Marshal(preset); err != nil {
+		return err
+	}
+	if data, err = json.MarshalIndent(file, "", "  "); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -1,53 +1,72 @@
 package game
 
 import (
+	"math"
 	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
 
 	"github.com/saniapro/tetris/pkg/tetris"
 )
 
-const strFill = "██"
+// Glyphs of the default theme.
+const (
+	strFill  = "██"
+	strGhost = "░░" // landing shadow, dimmer than strFill
+	strPeek  = "▄▄" // lower half of a block just above the field
+)
 
 // DrawBoard renders the entire game state to the terminal.
-// Draws the playing field, borders, current piece, next piece, and game statistics (score, level, lines).
+// Draws the playing field, borders, landing shadow, current piece, next and held pieces, and game statistics (score, level, lines).
 // Called once per game tick to update the display.
 func (gs *GameState) DrawBoard() {
 	gs.ClearScreen()
 	// Implementation to draw the game state on the screen
+	width, height, hidden := gs.Board.Width(), gs.Board.Height(), gs.Board.Hidden()
 
-	for i := 0; i < tetris.BoardHeight; i++ {
-		row := gs.Board.Row(i)
+	// only the visible rows are drawn, the hidden buffer above stays off screen
+	for i := 0; i < height; i++ {
+		row := gs.Board.Row(hidden + i)
 		for j := 0; j < len(row); j++ {
 			if row.CellFilled(j) {
 
 				gs.R.PutStrColor(tetris.BoardXOffset+j*2+1,
 					i+tetris.BoardYOffset+1,
-					strFill,
-					row[j])
+					gs.Theme.Fill,
+					gs.Theme.color(row[j]))
 			}
 		}
 		// Draw borders
 		if gs.R != nil {
-			gs.R.PutStr(tetris.BoardXOffset, i+tetris.BoardYOffset+1, "║")
-			gs.R.PutStr(tetris.BoardWidth*2+tetris.BoardXOffset+1, i+tetris.BoardYOffset+1, "║")
+			gs.R.PutStrColor(tetris.BoardXOffset, i+tetris.BoardYOffset+1, "║", gs.Theme.Border)
+			gs.R.PutStrColor(width*2+tetris.BoardXOffset+1, i+tetris.BoardYOffset+1, "║", gs.Theme.Border)
 		}
 	}
-	const borderLine = "════════════════════"
+	borderLine := strings.Repeat("═", width*2)
 	if gs.R != nil {
-		gs.R.PutStr(tetris.BoardXOffset+1, tetris.BoardYOffset, borderLine)
-		gs.R.PutStr(tetris.BoardXOffset+1, tetris.BoardHeight+tetris.BoardYOffset+1, borderLine)
+		gs.R.PutStrColor(tetris.BoardXOffset+1, tetris.BoardYOffset, borderLine, gs.Theme.Border)
+		gs.R.PutStrColor(tetris.BoardXOffset+1, height+tetris.BoardYOffset+1, borderLine, gs.Theme.Border)
 
-		gs.R.PutStr(tetris.BoardXOffset, tetris.BoardYOffset, "╔")
-		gs.R.PutStr(tetris.BoardWidth*2+tetris.BoardXOffset+1, tetris.BoardYOffset, "╗")
-		gs.R.PutStr(tetris.BoardXOffset, tetris.BoardHeight+tetris.BoardYOffset+1, "╚")
-		gs.R.PutStr(tetris.BoardWidth*2+tetris.BoardXOffset+1, tetris.BoardHeight+tetris.BoardYOffset+1, "╝")
+		gs.R.PutStrColor(tetris.BoardXOffset, tetris.BoardYOffset, "╔", gs.Theme.Border)
+		gs.R.PutStrColor(width*2+tetris.BoardXOffset+1, tetris.BoardYOffset, "╗", gs.Theme.Border)
+		gs.R.PutStrColor(tetris.BoardXOffset, height+tetris.BoardYOffset+1, "╚", gs.Theme.Border)
+		gs.R.PutStrColor(width*2+tetris.BoardXOffset+1, height+tetris.BoardYOffset+1, "╝", gs.Theme.Border)
+		gs.drawPeekRow()
 	}
 
-	//draw current piece
-	gs.DrawPiece(gs.Current, tetris.BoardXOffset+1, tetris.BoardYOffset+1)
+	gs.drawClearing()
+
+	if gs.Active() {
+		//draw landing shadow first so the current piece covers it when they overlap
+		gs.drawBoardPiece(gs.Ghost(), gs.Theme.Ghost)
+
+		//draw current piece
+		gs.drawBoardPiece(gs.Current, gs.Theme.Fill)
+	}
 
 	//draw score and level
-	xOffset := tetris.BoardWidth*2 + tetris.BoardXOffset + 4
+	xOffset := width*2 + tetris.BoardXOffset + 4
 	if gs.R != nil {
 		gs.R.PutStr(xOffset, tetris.BoardYOffset, "Score:")
 		tStr := strconv.Itoa(gs.Score)
@@ -58,30 +77,145 @@ func (gs *GameState) DrawBoard() {
 		gs.R.PutStr(xOffset, tetris.BoardYOffset+5, "Lines:")
 		gs.R.PutStr(xOffset+6, tetris.BoardYOffset+6, strconv.Itoa(gs.Lines))
 
-		gs.DrawPiece(gs.Next, xOffset, tetris.BoardYOffset+7)
-		gs.R.PutStr(xOffset, tetris.BoardYOffset+11, "Tetris Rate: "+gs.TetrisRate.GetPercent())
+		gs.drawPreview(xOffset + 20)
+		gs.R.PutStr(xOffset, tetris.BoardYOffset+7, "Tetris Rate: "+gs.TetrisRate.GetPercent())
+
+		// hold slot, greyed out once used for the current drop
+		gs.R.PutStr(xOffset, tetris.BoardYOffset+9, "Hold:")
+		if gs.Hold != nil {
+			hold := gs.hudPiece(*gs.Hold)
+			if gs.HoldUsed {
+				hold.Color = tcell.ColorGray
+			}
+			gs.DrawPiece(hold, xOffset, tetris.BoardYOffset+10)
+		}
+
+		// flash the latest special clear
+		if gs.Frame < gs.ClearUntil {
+			gs.R.PutStrColor(xOffset, tetris.BoardYOffset+13, gs.LastClear.Name(), tcell.ColorYellow)
+			if gs.LastClear.Combo > 0 {
+				gs.R.PutStr(xOffset, tetris.BoardYOffset+14, "COMBO x"+strconv.Itoa(gs.LastClear.Combo))
+			}
+		}
 	}
-	gs.SelectCount = 0
 
 	if gs.GameOver {
 		if gs.R != nil {
-			gs.R.PutStr(tetris.BoardXOffset+6, tetris.BoardYOffset+10, "GAME OVER")
+			const gameOver = "GAME OVER"
+			gs.R.PutStr(tetris.BoardXOffset+1+max(width*2-len(gameOver), 0)/2, tetris.BoardYOffset+height/2, gameOver)
+			if reason := strings.ToUpper(gs.EndReason.String()); reason != "" {
+				gs.R.PutStr(tetris.BoardXOffset+1+max(width*2-len(reason), 0)/2, tetris.BoardYOffset+height/2+1, reason)
+			}
+			// below the board, the seed is too long to fit inside it
+			seed := "Seed: " + strconv.FormatInt(gs.Seed, 10)
+			gs.R.PutStr(tetris.BoardXOffset, height+tetris.BoardYOffset+2, seed)
 		}
 	}
 	if gs.R != nil {
+		if gs.Status != "" {
+			gs.R.PutStr(tetris.BoardXOffset, height+tetris.BoardYOffset+3, gs.Status)
+		}
 		gs.R.Show()
 	}
 }
 
-// DrawPiece renders a tetromino piece at the given screen position with its assigned color.
+// previewSpacing is the number of rows each piece takes in the next queue.
+const previewSpacing = 3
+
+// drawPreview renders the next queue as a column starting at screen column x.
+func (gs *GameState) drawPreview(x int) {
+	gs.R.PutStr(x, tetris.BoardYOffset, "Next:")
+	for i, p := range gs.Preview(gs.PreviewSize) {
+		gs.DrawPiece(gs.hudPiece(p), x, tetris.BoardYOffset+1+i*previewSpacing)
+	}
+}
+
+// hudPiece moves a piece in spawn position to the top-left corner of its
+// matrix, for drawing outside the board.
+func (gs *GameState) hudPiece(p tetris.Piece) tetris.Piece {
+	p.X = 0
+	p.Y -= gs.Board.Hidden()
+	return p
+}
+
+// boardCell returns the screen position of a board cell, and whether it is in
+// the visible field or, with PeekRow set, in the peek row drawn on the top border.
+func (gs *GameState) boardCell(row, col int) (x, y int, visible, peek bool) {
+	r := row - gs.Board.Hidden()
+	x, y = tetris.BoardXOffset+col*2+1, tetris.BoardYOffset+1+r
+	return x, y, r >= 0, r == -1 && gs.PeekRow
+}
+
+// drawBoardPiece renders a piece in board coordinates with glyph, clipped to
+// the visible field. Cells in the peek row are drawn with the peek glyph.
+func (gs *GameState) drawBoardPiece(p tetris.Piece, glyph string) {
+	if gs.R == nil {
+		return
+	}
+	for i, row := range p.Matrix {
+		for j, cell := range row {
+			if cell != tetris.Fill {
+				continue
+			}
+			x, y, visible, peek := gs.boardCell(p.Y+i, p.X+j)
+			switch {
+			case visible:
+				gs.R.PutStrColor(x, y, glyph, gs.Theme.color(p.Color))
+			case peek:
+				gs.R.PutStrColor(x, y, gs.Theme.Peek, gs.Theme.color(p.Color))
+			}
+		}
+	}
+}
+
+// drawPeekRow shows the lowest hidden row on the top border, so blocks just
+// above the visible field are not completely invisible.
+func (gs *GameState) drawPeekRow() {
+	if !gs.PeekRow {
+		return
+	}
+	row := gs.Board.Row(gs.Board.Hidden() - 1)
+	for j := range row {
+		if row.CellFilled(j) {
+			x, y, _, _ := gs.boardCell(gs.Board.Hidden()-1, j)
+			gs.R.PutStrColor(x, y, gs.Theme.Peek, gs.Theme.color(row[j]))
+		}
+	}
+}
+
+// drawClearing animates rows removed during the line clear delay: the cells
+// vanish from the middle of the row outwards as the delay runs out.
+func (gs *GameState) drawClearing() {
+	rows, progress := gs.Clearing()
+	if gs.R == nil || len(rows) == 0 {
+		return
+	}
+	width := gs.Board.Width()
+	center, gone := float64(width)/2, progress*float64(width)/2
+	for _, i := range rows {
+		for j := range width {
+			if x, y, visible, _ := gs.boardCell(i, j); visible && math.Abs(float64(j)+0.5-center) < gone {
+				gs.R.PutStr(x, y, "  ")
+			}
+		}
+	}
+}
+
+// DrawPiece renders a tetromino piece at the given screen position in its theme color,
+// without clipping; board pieces are drawn with drawBoardPiece.
 // xOffset and yOffset specify the top-left corner where the piece matrix begins.
 // Each filled cell in the piece is rendered using the piece's color.
 func (gs *GameState) DrawPiece(p tetris.Piece, xOffset, yOffset int) {
+	gs.drawPieceStr(p, xOffset, yOffset, gs.Theme.Fill)
+}
+
+// drawPieceStr renders every filled cell of the piece using the given glyph.
+func (gs *GameState) drawPieceStr(p tetris.Piece, xOffset, yOffset int, glyph string) {
 	for i, row := range p.Matrix {
 		for j, cell := range row {
 			if cell == tetris.Fill {
 				if gs.R != nil {
-					gs.R.PutStrColor((p.X+j)*2+xOffset, p.Y+i+yOffset, strFill, p.Color)
+					gs.R.PutStrColor((p.X+j)*2+xOffset, p.Y+i+yOffset, glyph, gs.Theme.color(p.Color))
 				}
 			}
 		}
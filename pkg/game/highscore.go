@@ -0,0 +1,244 @@
+package game
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// HighScoreVersion is the high-score file format written by this version.
+const HighScoreVersion = 1
+
+// Size of each mode's table and of the initials players enter.
+const (
+	highScoreLimit = 10
+	initialsLen    = 3
+)
+
+// ScoreEntry is one finished game in the high-score table.
+type ScoreEntry struct {
+	Name     string        `json:"name"`
+	Score    int           `json:"score"`
+	Lines    int           `json:"lines"`
+	Level    int           `json:"level"`
+	Duration time.Duration `json:"duration"`
+	Seed     int64         `json:"seed"`
+	Mode     string        `json:"mode"`
+	Date     time.Time     `json:"date"`
+}
+
+// HighScores keeps the best games of every mode. Scores are only compared
+// within a mode, since rules and randomizers change how much a game is worth.
+type HighScores struct {
+	Version int          `json:"version"`
+	Entries []ScoreEntry `json:"entries"`
+}
+
+// DefaultHighScorePath returns the high-score file location under DataDir.
+func DefaultHighScorePath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "highscores.json"), nil
+}
+
+// LoadHighScores reads the high-score file at path. A missing file is an empty table.
+func LoadHighScores(path string) (*HighScores, error) {
+	hs := &HighScores{Version: HighScoreVersion}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return hs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, hs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if hs.Version != HighScoreVersion {
+		return nil, fmt.Errorf("%s: unsupported high-score version %d", path, hs.Version)
+	}
+	return hs, nil
+}
+
+// Save writes the table to path, creating its directory.
+func (hs *HighScores) Save(path string) error {
+	data, err := json.MarshalIndent(hs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Top returns the best entries of mode, highest score first.
+func (hs *HighScores) Top(mode string) []ScoreEntry {
+	var top []ScoreEntry
+	for _, e := range hs.Entries {
+		if e.Mode == mode {
+			top = append(top, e)
+		}
+	}
+	// equal scores keep their order, so the older entry ranks first
+	slices.SortStableFunc(top, func(a, b ScoreEntry) int { return cmp.Compare(b.Score, a.Score) })
+	return top[:min(len(top), highScoreLimit)]
+}
+
+// Rank returns the position a score would take in the table of mode, or -1
+// if it does not make the top ten.
+func (hs *HighScores) Rank(mode string, score int) int {
+	top := hs.Top(mode)
+	for i, e := range top {
+		if score > e.Score {
+			return i
+		}
+	}
+	if len(top) < highScoreLimit {
+		return len(top)
+	}
+	return -1
+}
+
+// Add inserts e and drops entries of its mode that fell out of the top ten.
+func (hs *HighScores) Add(e ScoreEntry) {
+	hs.Entries = append(hs.Entries, e)
+	top := hs.Top(e.Mode)
+	hs.Entries = slices.DeleteFunc(hs.Entries, func(x ScoreEntry) bool { return x.Mode == e.Mode })
+	hs.Entries = append(hs.Entries, top...)
+}
+
+// Mode names the rules a game is ranked under: rotation system, scoring rule,
+// gravity curve, randomizer and, when it is not the standard 10x20, board size.
+func (gs *GameState) Mode() string {
+	parts := []string{gs.Rotation.Name(), gs.Scoring.Name(), gs.Gravity.Name(), gs.Generator.Name()}
+	if w, h := gs.Board.Width(), gs.Board.Height(); w != tetris.BoardWidth || h != tetris.BoardHeight {
+		parts = append(parts, fmt.Sprintf("%dx%d", w, h))
+	}
+	return strings.Join(parts, "/")
+}
+
+// scoreEntry returns the high-score entry for the finished game.
+func (gs *GameState) scoreEntry() ScoreEntry {
+	return ScoreEntry{
+		Score:    gs.Score,
+		Lines:    gs.Lines,
+		Level:    gs.Level.Get(),
+		Duration: time.Duration(gs.Frame) * frameInterval,
+		Seed:     gs.Seed,
+		Mode:     gs.Mode(),
+		Date:     time.Now(),
+	}
+}
+
+// HighScoreScreen shows the top ten of the game's mode after game over. When
+// the score makes the table the player is asked for initials, the entry is
+// saved to path and highlighted. Returns once a key is pressed; keys pressed
+// before the screen opened are ignored.
+func HighScoreScreen(gs *GameState, path string) error {
+	// keys from play may still be queued; only those pressed from now count
+	since := time.Now()
+	hs, err := LoadHighScores(path)
+	if err != nil {
+		gs.Status = err.Error()
+		gs.DrawBoard()
+		gs.waitKeySince(since)
+		return err
+	}
+
+	entry := gs.scoreEntry()
+	mode := entry.Mode
+	rank := hs.Rank(mode, entry.Score)
+	if rank < 0 {
+		gs.drawHighScores(hs.Top(mode), -1, "Press any key")
+		gs.waitKeySince(since)
+		return nil
+	}
+
+	// insert a provisional entry so the initials are typed in place
+	top := slices.Insert(hs.Top(mode), rank, entry)
+	top = top[:min(len(top), highScoreLimit)]
+	var name []rune
+	for {
+		top[rank].Name = string(name)
+		gs.drawHighScores(top, rank, "New high score! Enter your initials: "+string(name)+"_")
+		e := gs.waitKeySince(since)
+		if e == nil || e.Key() == tcell.KeyEnter || e.Key() == tcell.KeyEsc {
+			break
+		}
+		switch {
+		case e.Key() == tcell.KeyBackspace, e.Key() == tcell.KeyBackspace2:
+			if len(name) > 0 {
+				name = name[:len(name)-1]
+			}
+		case e.Key() == tcell.KeyRune && len(name) < initialsLen && e.Rune() > ' ':
+			name = append(name, []rune(strings.ToUpper(string(e.Rune())))...)
+		}
+	}
+	if len(name) == 0 {
+		name = []rune("???")
+	}
+	entry.Name = string(name)
+	top[rank].Name = entry.Name
+	hs.Add(entry)
+	err = hs.Save(path)
+
+	prompt := "Press any key"
+	if err != nil {
+		prompt = err.Error()
+	}
+	gs.drawHighScores(top, rank, prompt)
+	gs.waitKeySince(since)
+	return err
+}
+
+// drawHighScores renders the game-over screen with the high-score table,
+// highlighting the row at index mark and showing prompt below the table.
+func (gs *GameState) drawHighScores(top []ScoreEntry, mark int, prompt string) {
+	if gs.R == nil {
+		return
+	}
+	gs.R.Clear()
+	x, y := tetris.BoardXOffset, tetris.BoardYOffset
+	gameOver := "GAME OVER"
+	if reason := gs.EndReason.String(); reason != "" {
+		gameOver += " - " + strings.ToUpper(reason)
+	}
+	gs.R.PutStrColor(x, y, gameOver, tcell.ColorRed)
+	gs.R.PutStr(x, y+1, fmt.Sprintf("Score %d  Lines %d  Seed %d", gs.Score, gs.Lines, gs.Seed))
+	gs.R.PutStr(x, y+3, "HIGH SCORES  "+gs.Mode())
+	gs.R.PutStr(x, y+4, " #  NAME     SCORE  LINES  LVL    TIME  DATE")
+	for i, e := range top {
+		row := fmt.Sprintf("%2d. %-4s %8d %6d %4d %7s  %s",
+			i+1, e.Name, e.Score, e.Lines, e.Level, formatDuration(e.Duration), e.Date.Format("2006-01-02"))
+		if i == mark {
+			gs.R.PutStrColor(x, y+5+i, row, tcell.ColorYellow)
+		} else {
+			gs.R.PutStr(x, y+5+i, row)
+		}
+	}
+	if len(top) == 0 {
+		gs.R.PutStr(x, y+5, "no scores yet")
+	}
+	gs.R.PutStr(x, y+6+highScoreLimit, prompt)
+	gs.R.Show()
+}
+
+// formatDuration renders a game length as m:ss.
+func formatDuration(d time.Duration) string {
+	s := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -6,20 +6,65 @@ import (
 	"github.com/saniapro/tetris/pkg/tetris"
 )
 
-// Init initializes a new GameState with a fresh board, spawns initial pieces,
-// and sets up the renderer and tetromino bag generator.
+// Options selects the rules a new game is played with.
+// Use DefaultOptions to start from the standard rules.
+type Options struct {
+	tetris.Rules
+	Randomizer string   // Name of the piece randomizer, see tetris.Randomizers
+	Seed       int64    // Seed of the piece sequence, 0 picks one from the clock
+	Preview    int      // Upcoming pieces shown, MinPreview to MaxPreview
+	PeekRow    bool     // Show the lowest hidden row on the top border
+	Theme      Theme    // Piece colors and glyphs
+	Bindings   Bindings // Keys of every action
+}
+
+// Bounds and default of the next queue length.
+const (
+	MinPreview     = 1
+	MaxPreview     = 6
+	DefaultPreview = 5
+)
+
+// DefaultOptions returns the standard guideline rules.
+func DefaultOptions() Options {
+	bindings, _ := NewBindings("default", nil)
+	return Options{
+		Rules:      tetris.DefaultRules(),
+		Randomizer: "7bag",
+		Preview:    DefaultPreview,
+		Theme:      defaultTheme(),
+		Bindings:   bindings,
+	}
+}
+
+// Init initializes a new GameState with a fresh engine, spawns initial pieces,
+// and sets up the renderer and piece randomizer.
+// All randomness comes from the seed, so the same options replay the same game.
+// Every input is recorded into gs.Replay.
 // Returns a ready-to-play GameState.
-func Init() *GameState {
+func Init(opts Options) (*GameState, error) {
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	gen, err := tetris.NewRandomizer(opts.Randomizer, opts.Seed)
+	if err != nil {
+		return nil, err
+	}
 	gs := &GameState{
-		Board:      tetris.NewBoard(),
-		R:          &ScreenRenderer{},
-		Level:      tetris.Level{Number: 1},
-		TetrisRate: &tetris.TetrisRate{},
-		Generator:  tetris.NewBagGenerator(time.Now().UnixNano()),
+		Engine:      tetris.NewEngine(opts.Rules, gen),
+		Seed:        opts.Seed,
+		PreviewSize: min(max(opts.Preview, MinPreview), MaxPreview),
+		PeekRow:     opts.PeekRow,
+		Theme:       opts.Theme,
+		Bindings:    opts.Bindings,
+		R:           &ScreenRenderer{},
+		Replay: &tetris.Replay{
+			Version:    tetris.ReplayVersion,
+			Seed:       opts.Seed,
+			Randomizer: gen.Name(),
+			Rules:      opts.Rules.Config(),
+		},
 	}
-
-	gs.Current = tetris.SpawnPiece(gs.Generator.Next())
-	gs.Next = tetris.SpawnPiece(gs.Generator.Next())
-
-	return gs
+	gs.Record(gs.Replay)
+	return gs, nil
 }
@@ -4,40 +4,32 @@ import (
 	"github.com/gdamore/tcell/v2"
 )
 
-// HandleInput processes keyboard events and translates them to game actions.
-// Arrow keys move/rotate pieces, spacebar triggers hard drop, +/- adjust level, 'p' pauses, 'q' and Esc quit.
+// HandleInput processes keyboard events and translates them to actions through
+// the key bindings. Game actions become engine inputs applied on the next frame;
+// the others pause, restart or quit the game, leaving a quit game to be saved,
+// or open the key binding screen.
 func HandleInput(gs *GameState, ev tcell.Event) {
-	switch e := ev.(type) {
-	case *tcell.EventKey:
-		switch e.Key() {
-		case tcell.KeyLeft:
-			gs.MovePiece(-1, 0)
-		case tcell.KeyRight:
-			gs.MovePiece(1, 0)
-		case tcell.KeyDown:
-			gs.MovePiece(0, 1)
-		case tcell.KeyUp:
-			gs.RotatePiece()
-		case tcell.KeyEsc:
-			gs.GameOver = true
-		case tcell.KeyRune:
-			switch e.Rune() {
-			case 'q':
-				gs.GameOver = true
-			case ' ':
-				gs.HardDrop()
-			case 'p':
-				// Pause functionality can be implemented here
-				screen.PollEvent() // simple pause until next key press
-			case '-':
-				if gs.DecreaseLevel() {
-					gs.SetTickerInterval(gs.Level.Number)
-				}
-			case '+':
-				if gs.IncreaseLevel() {
-					gs.SetTickerInterval(gs.Level.Number)
-				}
-			}
-		}
+	e, ok := ev.(*tcell.EventKey)
+	if !ok {
+		return
+	}
+	a, ok := gs.Bindings.Action(e)
+	if !ok {
+		return
+	}
+	if in, ok := a.Input(); ok {
+		gs.keys.Event(gs.Engine, in, e.When())
+		return
+	}
+	switch a {
+	case ActionQuit:
+		gs.Quit = true
+	case ActionRestart:
+		gs.Restart = true
+	case ActionPause:
+		// Pause functionality can be implemented here
+		gs.waitKey() // simple pause until next key press
+	case ActionKeyBindings:
+		gs.KeyBindingScreen()
 	}
 }
@@ -0,0 +1,95 @@
+package game
+
+import (
+	"time"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// repeatGap is the longest gap between two events of the same key that can
+// still be terminal auto-repeat rather than a new press.
+const repeatGap = 100 * time.Millisecond
+
+// repeatJitter is how much two auto-repeat gaps may differ and still count as
+// the steady OS repeat rate.
+const repeatJitter = 20 * time.Millisecond
+
+// Bounds of the silence after which a held key counts as released.
+const (
+	minReleaseWait = 50 * time.Millisecond
+	maxReleaseWait = 200 * time.Millisecond
+)
+
+// keyState is what holdTracker knows about the key behind one input.
+type keyState struct {
+	last      time.Time     // Time of the last event
+	gap       time.Duration // Gap before the last event, 0 for the first
+	interval  time.Duration // Last observed auto-repeat interval
+	repeating bool          // The events are auto-repeat of a held key
+	held      bool          // The engine was told the key is held
+}
+
+// holdTracker infers key holds from terminal auto-repeat. Terminals report a
+// press followed by repeats at the OS rate but no release (tcell does not
+// surface kitty keyboard protocol release events). Every event is a tap until
+// two short gaps in a row match, which only the steady OS repeat rate
+// produces; quick taps by hand stay separate presses. From then on the key is
+// held until its repeats stop, and the engine applies its own ARR.
+// A hold is thus recognised only after the OS repeat delay and two repeats,
+// with DAS already charged, so that time takes the place of DAS in a terminal.
+type holdTracker struct {
+	keys [tetris.NumInputs]keyState
+}
+
+// Event reports a key event for in received at now to the engine.
+// Auto-repeats of keys that do not repeat in the engine are dropped.
+func (t *holdTracker) Event(e *tetris.Engine, in tetris.Input, now time.Time) {
+	k := &t.keys[in]
+	var gap time.Duration
+	if !k.last.IsZero() {
+		gap = now.Sub(k.last)
+	}
+	short := gap > 0 && gap < repeatGap
+	k.repeating = short && (k.repeating || steady(k.gap, gap))
+	k.last, k.gap = now, gap
+	if !k.repeating {
+		if k.held {
+			e.Release(in)
+			k.held = false
+		}
+		e.Press(in)
+		if in.Held() {
+			// a tap until auto-repeat proves otherwise
+			e.Release(in)
+		}
+		return
+	}
+	k.interval = gap
+	if in.Held() && !k.held {
+		k.held = true
+		e.Repeat(in)
+	}
+}
+
+// steady reports whether gap b follows gap a at the same short interval, as
+// auto-repeat does.
+func steady(a, b time.Duration) bool {
+	return a > 0 && a < repeatGap && (a-b).Abs() <= repeatJitter
+}
+
+// Update ends the auto-repeat of keys whose repeats stopped before now and
+// releases them in the engine.
+func (t *holdTracker) Update(e *tetris.Engine, now time.Time) {
+	for in := range t.keys {
+		k := &t.keys[in]
+		wait := min(max(3*k.interval, minReleaseWait), maxReleaseWait)
+		if !k.repeating || now.Sub(k.last) <= wait {
+			continue
+		}
+		k.repeating = false
+		if k.held {
+			e.Release(tetris.Input(in))
+			k.held = false
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// playKeys feeds events of in at the given times, in milliseconds, through a
+// holdTracker into a fresh engine on a wide board, stepping it frame by frame
+// until end. It returns the engine and the tracker.
+func playKeys(in tetris.Input, end int, times ...int) (*tetris.Engine, *holdTracker) {
+	rules := tetris.DefaultRules()
+	rules.Width = 20
+	rules.ARR = 6
+	e := tetris.NewEngine(rules, tetris.NewBagGenerator(1))
+	e.Current = tetris.SRS{}.Spawn(tetris.PieceT)
+	e.Current.X += (20 - tetris.BoardWidth) / 2
+	e.Current.Y += e.Board.Hidden()
+
+	var t holdTracker
+	start := time.Unix(1000, 0)
+	for now := time.Duration(0); now <= time.Duration(end)*time.Millisecond; now += frameInterval {
+		for len(times) > 0 && time.Duration(times[0])*time.Millisecond <= now {
+			t.Event(e, in, start.Add(time.Duration(times[0])*time.Millisecond))
+			times = times[1:]
+		}
+		t.Update(e, start.Add(now))
+		e.Step()
+	}
+	return e, &t
+}
+
+func TestHoldTrackerTaps(t *testing.T) {
+	tests := []struct {
+		name  string
+		times []int
+		moved int
+	}{
+		{"tap", []int{0}, 1},
+		{"double tap", []int{0, 80}, 2},
+		{"double tap after a pause", []int{0, 400, 480}, 3},
+		{"taps at the OS repeat delay", []int{0, 300, 600, 900}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := tetris.SRS{}.Spawn(tetris.PieceT).X + 5
+			e, tr := playKeys(tetris.InputRight, 1500, tt.times...)
+			if got := e.Current.X - x; got != tt.moved {
+				t.Errorf("moved %d cells, want %d", got, tt.moved)
+			}
+			if tr.keys[tetris.InputRight].held {
+				t.Error("key still held")
+			}
+		})
+	}
+}
+
+// repeats returns a press at 0 followed by OS auto-repeat: the first repeat
+// after delay, then one every interval until end, all in milliseconds.
+func repeats(delay, interval, end int) []int {
+	times := []int{0}
+	for t := delay; t <= end; t += interval {
+		times = append(times, t)
+	}
+	return times
+}
+
+func TestHoldTrackerRepeat(t *testing.T) {
+	x := tetris.SRS{}.Spawn(tetris.PieceT).X + 5
+	// the key is held from 0 to 1000ms and auto-repeats from 400ms
+	times := repeats(400, 33, 1000)
+
+	e, tr := playKeys(tetris.InputRight, 700, times...)
+	if !tr.keys[tetris.InputRight].held {
+		t.Fatal("auto-repeat did not hold the key")
+	}
+	// three events count as taps before the repeat rate is steady,
+	// then the engine shifts every 6 frames
+	if moved := e.Current.X - x; moved < 5 {
+		t.Errorf("held key moved %d cells by 700ms, want at least 5", moved)
+	}
+
+	e, tr = playKeys(tetris.InputRight, 1500, times...)
+	if tr.keys[tetris.InputRight].held {
+		t.Error("key still held after its repeats stopped")
+	}
+	stopped := e.Current.X
+	for range 30 {
+		e.Step()
+	}
+	if e.Current.X != stopped {
+		t.Errorf("piece kept moving after the release: x %d, then %d", stopped, e.Current.X)
+	}
+}
+
+func TestHoldTrackerRotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		times []int
+		turns int
+	}{
+		{"double tap", []int{0, 80}, 2},
+		{"held", repeats(400, 33, 700), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := playKeys(tetris.InputRotateCW, 1000, tt.times...)
+			if got := e.Current.Rotation; got != tt.turns%4 {
+				t.Errorf("rotation state %d, want %d", got, tt.turns%4)
+			}
+		})
+	}
+}
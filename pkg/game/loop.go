@@ -4,53 +4,26 @@ import (
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/saniapro/tetris/pkg/tetris"
 )
 
-// Loop runs the main game loop until game over.
-// Handles timed piece drops, processes input events, and updates the display.
-// Uses a ticker for consistent drop speed and a goroutine for non-blocking event polling.
+// frameInterval is the duration of one engine frame.
+const frameInterval = time.Second / tetris.FrameRate
+
+// Loop drives the engine until game over or until the player quits or restarts.
+// Steps the engine once per frame tick and redraws, while input events are only
+// queued and take effect on the next frame.
+// Uses a frame ticker for consistent timing and a goroutine for non-blocking event polling.
 func Loop(gs *GameState) {
-	gs.Ticker = time.NewTicker(time.Millisecond * 500) // швидкість падіння
+	gs.Ticker = time.NewTicker(frameInterval) // один тік - один кадр рушія
 	defer gs.Ticker.Stop()
-	evCh := make(chan tcell.Event, 16) // буфер корисний при сплесках подій
-	quit := make(chan struct{})
-
-	// goroutine для PollEvent
-	go func() {
-		defer close(evCh)
-		for {
-			select {
-			case <-quit:
-				return
-			default:
-				ev := screen.PollEvent() // блокує тут, але не в головній горутині
-				if ev == nil {
-					continue
-				}
-				evCh <- ev
-			}
-		}
-	}()
+	evCh := events()
 
-	for !gs.GameOver {
+	for !gs.GameOver && !gs.Quit && !gs.Restart {
 		select {
-		case <-gs.Ticker.C:
-			gs.MovePiece(0, 1)
-			lines := gs.ClearLines()
-			if lines > 0 {
-				gs.TetrisRate.AddTetraLines(lines)
-				gs.UpdateScore(lines)
-				if gs.UpdateLevel() {
-					// adjust tick speed based on level
-					gs.SetTickerInterval(gs.Level.Number)
-				}
-				// track total cleared lines
-				gs.Lines += lines
-			}
-			if gs.IsGameOver() {
-				gs.GameOver = true
-			}
-			gs.EventName = "tick"
+		case now := <-gs.Ticker.C:
+			gs.keys.Update(gs.Engine, now)
+			gs.Step()
 			gs.DrawBoard()
 
 		case ev := <-evCh:
@@ -58,19 +31,52 @@ func Loop(gs *GameState) {
 				continue
 			}
 			HandleInput(gs, ev)
-			gs.EventName = "input"
-			gs.DrawBoard()
-			gs.SelectCount++
 		}
 
 	}
-	close(quit)
+}
+
+// events returns the channel terminal events are delivered on. The polling
+// goroutine is started on first use and shared by every screen and every game
+// until the terminal is restored, so no key press is lost between them.
+func events() <-chan tcell.Event {
+	if screenEvents != nil {
+		return screenEvents
+	}
+	evCh := make(chan tcell.Event, 16) // буфер корисний при сплесках подій
+
+	// goroutine для PollEvent
+	go func() {
+		defer close(evCh)
+		for {
+			ev := screen.PollEvent() // блокує тут, але не в головній горутині
+			if ev == nil {
+				return // the screen was finalized
+			}
+			evCh <- ev
+		}
+	}()
+	screenEvents = evCh
+	return evCh
+}
+
+// waitKey blocks until the next key press.
+func (gs *GameState) waitKey() *tcell.EventKey {
+	for ev := range events() {
+		if e, ok := ev.(*tcell.EventKey); ok {
+			return e
+		}
+	}
+	return nil
+}
 
-	if gs.GameOver {
-		if gs.R != nil {
-			gs.R.PollEvent()
-		} else {
-			time.Sleep(time.Second)
+// waitKeySince is waitKey for keys pressed at or after since. Keys still
+// queued from before, such as those of the game just played, are skipped.
+func (gs *GameState) waitKeySince(since time.Time) *tcell.EventKey {
+	for {
+		e := gs.waitKey()
+		if e == nil || !e.When().Before(since) {
+			return e
 		}
 	}
 }
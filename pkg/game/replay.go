@@ -0,0 +1,121 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// replayExt is the file extension of saved replays.
+const replayExt = ".replay"
+
+// DefaultReplayPath returns a fresh file name for a replay recorded now,
+// inside the replays directory under DataDir.
+func DefaultReplayPath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	name := time.Now().Format("20060102-150405") + replayExt
+	return filepath.Join(dir, "replays", name), nil
+}
+
+// SaveReplay writes the recorded game to path, creating its directory.
+func (gs *GameState) SaveReplay(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gs.Replay.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadReplay reads a replay file saved by SaveReplay.
+func LoadReplay(path string) (*tetris.Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return tetris.ReadReplay(f)
+}
+
+// InitReplay creates a game with the seed and rules of rep, ready to be played
+// back with PlayReplay. Only display options are taken from opts.
+func InitReplay(rep *tetris.Replay, opts Options) (*GameState, error) {
+	rules, err := rep.Rules.Rules()
+	if err != nil {
+		return nil, err
+	}
+	opts.Rules = rules
+	opts.Randomizer = rep.Randomizer
+	opts.Seed = rep.Seed
+	return Init(opts)
+}
+
+// replaySpeeds are the playback speeds, in frames simulated per tick.
+var replaySpeeds = []int{1, 2, 4, 8, 16}
+
+// PlayReplay re-simulates rep on gs and renders it until the viewer quits.
+// Space or 'p' pauses, +/- change the speed, '.' or the right arrow steps one
+// frame while paused, and 'q' or Esc quit.
+func PlayReplay(gs *GameState, rep *tetris.Replay) {
+	player := tetris.NewReplayPlayer(rep, gs.Engine)
+	gs.Record(nil)
+
+	gs.Ticker = time.NewTicker(frameInterval)
+	defer gs.Ticker.Stop()
+	evCh := events()
+
+	speed, paused := 0, false
+	for {
+		select {
+		case <-gs.Ticker.C:
+			if !paused {
+				for range replaySpeeds[speed] {
+					player.Step()
+				}
+			}
+
+		case ev := <-evCh:
+			e, ok := ev.(*tcell.EventKey)
+			if !ok {
+				continue
+			}
+			switch {
+			case e.Key() == tcell.KeyEsc, e.Key() == tcell.KeyRune && e.Rune() == 'q':
+				return
+			case e.Key() == tcell.KeyRune && (e.Rune() == ' ' || e.Rune() == 'p'):
+				paused = !paused
+			case e.Key() == tcell.KeyRune && e.Rune() == '+':
+				speed = min(speed+1, len(replaySpeeds)-1)
+			case e.Key() == tcell.KeyRune && e.Rune() == '-':
+				speed = max(speed-1, 0)
+			case e.Key() == tcell.KeyRight, e.Key() == tcell.KeyRune && e.Rune() == '.':
+				if paused {
+					player.Step()
+				}
+			}
+		}
+
+		state := fmt.Sprintf("x%d", replaySpeeds[speed])
+		switch {
+		case player.Done():
+			state = "END"
+		case paused:
+			state = "PAUSED"
+		}
+		gs.Status = fmt.Sprintf("REPLAY %s  frame %d/%d", state, gs.Frame, rep.Frames)
+		gs.DrawBoard()
+	}
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// SaveVersion is the save file format written by this version.
+const SaveVersion = 3
+
+// SaveFile is an in-progress game written when the player quits.
+type SaveFile struct {
+	Version int              `json:"version"`
+	Seed    int64            `json:"seed"`
+	Engine  *tetris.Snapshot `json:"engine"`
+	Replay  *tetris.Replay   `json:"replay,omitempty"` // Inputs since the start, so the replay stays complete
+}
+
+// DefaultSavePath returns the save file location under DataDir.
+func DefaultSavePath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "save.json"), nil
+}
+
+// Save writes the game to path as versioned JSON, creating its directory.
+func (gs *GameState) Save(path string) error {
+	snap, err := gs.Snapshot()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(SaveFile{
+		Version: SaveVersion,
+		Seed:    gs.Seed,
+		Engine:  snap,
+		Replay:  gs.Replay,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Resume restores a game saved by Save. The save keeps its own rules; only
+// display options such as the preview length, theme and keys are taken from opts.
+func Resume(path string, opts Options) (*GameState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sf SaveFile
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if sf.Version != SaveVersion {
+		return nil, fmt.Errorf("%s: unsupported save version %d", path, sf.Version)
+	}
+	if sf.Engine == nil {
+		return nil, fmt.Errorf("%s: no game state", path)
+	}
+	e, err := tetris.RestoreEngine(sf.Engine)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	gs := &GameState{
+		Engine:      e,
+		Seed:        sf.Seed,
+		PreviewSize: min(max(opts.Preview, MinPreview), MaxPreview),
+		PeekRow:     opts.PeekRow,
+		Theme:       opts.Theme,
+		Bindings:    opts.Bindings,
+		R:           &ScreenRenderer{},
+		Replay:      sf.Replay,
+	}
+	if gs.Replay != nil {
+		gs.Record(gs.Replay)
+	}
+	return gs, nil
+}
@@ -9,12 +9,16 @@ import (
 // screen is the global tcell terminal screen used for rendering.
 var screen tcell.Screen
 
+// screenEvents delivers the events of screen, see events.
+var screenEvents <-chan tcell.Event
+
 // InitTerminal initializes the terminal for Tetris gameplay.
 // Sets up tcell screen, hides cursor, and clears the display.
 // Must be called before any rendering operations.
 func InitTerminal() {
 	var err error
 	screen, err = tcell.NewScreen()
+	screenEvents = nil
 	if err != nil {
 		log.Fatalf("Cannot create screen: %v", err)
 	}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/saniapro/tetris/pkg/tetris"
+)
+
+// Theme controls how the board is drawn: the color of every piece, the
+// border color and the glyphs used for blocks and the landing shadow.
+type Theme struct {
+	Pieces [7]tcell.Color // Color per piece index, see tetris.Pieces
+	Border tcell.Color
+	Fill   string // Two-column glyph of a filled cell
+	Ghost  string // Two-column glyph of the landing shadow
+	Peek   string // Two-column glyph of blocks in the peek row above the field
+}
+
+// pieceLetters names the pieces in config files, in piece index order.
+const pieceLetters = "IOTSZJL"
+
+// Themes are the built-in themes by name.
+var Themes = map[string]Theme{
+	"default": defaultTheme(),
+	"mono": {
+		Pieces: [7]tcell.Color{tcell.ColorWhite, tcell.ColorWhite, tcell.ColorWhite,
+			tcell.ColorWhite, tcell.ColorWhite, tcell.ColorWhite, tcell.ColorWhite},
+		Border: tcell.ColorDefault,
+		Fill:   strFill,
+		Ghost:  strGhost,
+		Peek:   strPeek,
+	},
+	// ascii works on terminals and fonts without block elements
+	"ascii": func() Theme {
+		t := defaultTheme()
+		t.Fill, t.Ghost, t.Peek = "[]", "..", "__"
+		return t
+	}(),
+}
+
+// defaultTheme uses the guideline piece colors.
+func defaultTheme() Theme {
+	t := Theme{Border: tcell.ColorDefault, Fill: strFill, Ghost: strGhost, Peek: strPeek}
+	for i, p := range tetris.Pieces {
+		t.Pieces[i] = p.Color
+	}
+	return t
+}
+
+// ThemeNames returns the names of the built-in themes, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for n := range Themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewTheme returns the built-in theme name with colors overridden: keys are
+// piece letters (I, O, T, S, Z, J, L) or "border", values are tcell color
+// names or #rrggbb.
+func NewTheme(name string, colors map[string]string) (Theme, error) {
+	t, ok := Themes[strings.ToLower(name)]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (want one of %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	for key, value := range colors {
+		c := tcell.GetColor(value)
+		if c == tcell.ColorDefault && value != "default" {
+			return Theme{}, fmt.Errorf("theme color %s: unknown color %q", key, value)
+		}
+		switch k := strings.ToUpper(key); {
+		case k == "BORDER":
+			t.Border = c
+		case len(k) == 1 && strings.Contains(pieceLetters, k):
+			t.Pieces[strings.Index(pieceLetters, k)] = c
+		default:
+			return Theme{}, fmt.Errorf("theme color %q: want a piece letter (%s) or border", key, pieceLetters)
+		}
+	}
+	return t, nil
+}
+
+// color maps a standard piece color, as stored on the board, to the theme.
+// Other colors, such as the greyed-out hold piece, are kept.
+func (t *Theme) color(c tcell.Color) tcell.Color {
+	for i, p := range tetris.Pieces {
+		if p.Color == c {
+			return t.Pieces[i]
+		}
+	}
+	return c
+}
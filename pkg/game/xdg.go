@@ -0,0 +1,22 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// appName names the per-user directories the game stores files in.
+const appName = "tetris"
+
+// DataDir returns the directory for replays and other game data:
+// $XDG_DATA_HOME/tetris, or ~/.local/share/tetris when the variable is unset.
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", appName), nil
+}
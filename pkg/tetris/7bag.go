@@ -1,38 +1,60 @@
 package tetris
 
-import rand "math/rand/v2"
+import (
+	"encoding/json"
+	"fmt"
+	rand "math/rand/v2"
+)
 
 // BagGenerator implements the 7-bag random tetromino selection algorithm.
 // Ensures each of the 7 tetrominoes appears exactly once per bag before reshuffling.
 // This prevents long droughts of specific pieces.
+// With more copies per bag (14-bag) the same idea allows short repeats.
 type BagGenerator struct {
-	bag []int      // Current bag of piece indices
-	i   int        // Current position in bag
-	rng *rand.Rand // Random number generator
+	bag    []int      // Current bag of piece indices
+	i      int        // Current position in bag
+	copies int        // Copies of each piece per bag
+	src    *rand.PCG  // Seeded source, kept for cloning and serialization
+	rng    *rand.Rand // Random number generator
 }
 
 // NewBagGenerator creates a new 7-bag generator with the given seed.
 // Immediately generates and shuffles the first bag.
 func NewBagGenerator(seed int64) *BagGenerator {
+	return NewMultiBagGenerator(seed, 1)
+}
+
+// NewMultiBagGenerator creates a bag generator holding copies of each piece per
+// bag, e.g. 2 for a 14-bag. Immediately generates and shuffles the first bag.
+func NewMultiBagGenerator(seed int64, copies int) *BagGenerator {
 	// Use PCG source from math/rand/v2 for a good seeded generator.
-	s := uint64(seed)
-	src := rand.NewPCG(s, s^0x9e3779b97f4a7c15)
+	src := newPCG(seed)
 	g := &BagGenerator{
-		rng: rand.New(src),
+		copies: max(copies, 1),
+		src:    src,
+		rng:    rand.New(src),
 	}
 	g.refill()
 	return g
 }
 
-// refill generates a new shuffled bag containing all 7 piece types (0-6).
+// refill generates a new shuffled bag containing every piece type (0-6) copies times.
 func (g *BagGenerator) refill() {
-	g.bag = []int{0, 1, 2, 3, 4, 5, 6}
+	g.bag = g.bag[:0]
+	for range g.copies {
+		g.bag = append(g.bag, 0, 1, 2, 3, 4, 5, 6)
+	}
 	g.rng.Shuffle(len(g.bag), func(i, j int) {
 		g.bag[i], g.bag[j] = g.bag[j], g.bag[i]
 	})
 	g.i = 0
 }
 
+// Name returns "7bag" or "14bag".
+func (g *BagGenerator) Name() string {
+	return fmt.Sprintf("%dbag", pieceCount*g.copies)
+}
+
 // Next returns the next piece index from the current bag.
 // Automatically refills the bag when exhausted.
 func (g *BagGenerator) Next() int {
@@ -43,3 +65,59 @@ func (g *BagGenerator) Next() int {
 	g.i++
 	return p
 }
+
+// Peek returns the next n piece indices, spanning bag boundaries, without
+// disturbing the sequence.
+func (g *BagGenerator) Peek(n int) []int {
+	return peek(g, n)
+}
+
+// Clone returns an independent generator continuing the same sequence.
+func (g *BagGenerator) Clone() Randomizer {
+	src := clonePCG(g.src)
+	return &BagGenerator{
+		bag:    append([]int(nil), g.bag...),
+		i:      g.i,
+		copies: g.copies,
+		src:    src,
+		rng:    rand.New(src),
+	}
+}
+
+// bagState is the serialized form of a BagGenerator.
+type bagState struct {
+	Bag    []int  `json:"bag"`
+	I      int    `json:"i"`
+	Copies int    `json:"copies"`
+	PCG    []byte `json:"pcg"`
+}
+
+// MarshalBinary encodes the bag, its position and the PCG state.
+func (g *BagGenerator) MarshalBinary() ([]byte, error) {
+	pcg, err := g.src.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(bagState{Bag: g.bag, I: g.i, Copies: g.copies, PCG: pcg})
+}
+
+// UnmarshalBinary restores a state produced by MarshalBinary.
+func (g *BagGenerator) UnmarshalBinary(data []byte) error {
+	var st bagState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	if err := checkPieces(st.Bag...); err != nil {
+		return fmt.Errorf("bag randomizer state: %w", err)
+	}
+	if st.I < 0 || st.I > len(st.Bag) {
+		return fmt.Errorf("bag randomizer state: position %d outside the bag", st.I)
+	}
+	src := &rand.PCG{}
+	if err := src.UnmarshalBinary(st.PCG); err != nil {
+		return err
+	}
+	g.bag, g.i, g.copies = st.Bag, st.I, max(st.Copies, 1)
+	g.src, g.rng = src, rand.New(src)
+	return nil
+}
@@ -0,0 +1,99 @@
+package tetris
+
+// ARS is the Arika Rotation System used by the TGM series.
+// Pieces spawn flat side up and stay bottom-aligned in their 3x3 box. A blocked
+// rotation is retried one cell right, then one cell left; the I piece never kicks,
+// and L, J and T refuse to kick when the first obstruction is in the center column.
+type ARS struct{}
+
+// arsShapes holds the ARS rotation states indexed by piece ID.
+var arsShapes = []shapeSet{
+	// I
+	{states: [][][]int{
+		{{0, 0, 0, 0}, {Fill, Fill, Fill, Fill}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+		{{0, 0, Fill, 0}, {0, 0, Fill, 0}, {0, 0, Fill, 0}, {0, 0, Fill, 0}},
+	}, x: 3, y: -1},
+	// O
+	{states: [][][]int{
+		{{Fill, Fill}, {Fill, Fill}},
+	}, x: 4, y: 0},
+	// T
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, Fill}, {0, Fill, 0}},
+		{{0, Fill, 0}, {Fill, Fill, 0}, {0, Fill, 0}},
+		{{0, 0, 0}, {0, Fill, 0}, {Fill, Fill, Fill}},
+		{{0, Fill, 0}, {0, Fill, Fill}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// S
+	{states: [][][]int{
+		{{0, 0, 0}, {0, Fill, Fill}, {Fill, Fill, 0}},
+		{{Fill, 0, 0}, {Fill, Fill, 0}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// Z
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, 0}, {0, Fill, Fill}},
+		{{0, 0, Fill}, {0, Fill, Fill}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// J
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, Fill}, {0, 0, Fill}},
+		{{0, Fill, 0}, {0, Fill, 0}, {Fill, Fill, 0}},
+		{{0, 0, 0}, {Fill, 0, 0}, {Fill, Fill, Fill}},
+		{{0, Fill, Fill}, {0, Fill, 0}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// L
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, Fill}, {Fill, 0, 0}},
+		{{Fill, Fill, 0}, {0, Fill, 0}, {0, Fill, 0}},
+		{{0, 0, 0}, {0, 0, Fill}, {Fill, Fill, Fill}},
+		{{0, Fill, 0}, {0, Fill, 0}, {0, Fill, Fill}},
+	}, x: 3, y: -1},
+}
+
+// Name returns "ars".
+func (ARS) Name() string { return "ars" }
+
+// Spawn returns piece id in its ARS spawn orientation.
+func (ARS) Spawn(id int) Piece {
+	return arsShapes[id].spawn(id)
+}
+
+// Rotate applies the ARS rotation and kick rules. 180° rotation is not supported.
+func (ARS) Rotate(p Piece, dir int, b *Board) (Piece, int, bool) {
+	if dir != RotateCW && dir != RotateCCW {
+		return p, -1, false
+	}
+	rotated := arsShapes[p.ID].rotate(p, dir)
+	if b.Fits(rotated) {
+		return rotated, 0, true
+	}
+	switch p.ID {
+	case PieceI, PieceO:
+		return p, -1, false
+	case PieceL, PieceJ, PieceT:
+		if arsCenterBlocked(rotated, b) {
+			return p, -1, false
+		}
+	}
+	for i, dx := range []int{1, -1} {
+		try := rotated
+		try.X += dx
+		if b.Fits(try) {
+			return try, i + 1, true
+		}
+	}
+	return p, -1, false
+}
+
+// arsCenterBlocked reports whether the first obstructed cell of the rotated
+// piece, scanning its box in reading order, lies in the center column.
+func arsCenterBlocked(p Piece, b *Board) bool {
+	for i, row := range p.Matrix {
+		for j, cell := range row {
+			if cell != 0 && b.CellFilled(p.Y+i, p.X+j) {
+				return j == 1
+			}
+		}
+	}
+	return false
+}
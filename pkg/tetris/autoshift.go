@@ -0,0 +1,145 @@
+package tetris
+
+// autoShift tracks the held movement keys between frames.
+type autoShift struct {
+	left, right bool // Move keys currently held
+	dir         int  // Active shift direction: -1 left, 1 right, 0 none
+	das         int  // Frames the active direction has been charging
+	arr         int  // Frames since the last repeated move
+	fresh       bool // The active direction started this frame
+	down        bool // Soft drop is held
+	downFrames  int  // Frames since the last soft drop row
+	downFresh   bool // Soft drop started this frame
+}
+
+// held reports whether the move key for dir is down.
+func (s *autoShift) held(dir int) bool {
+	if dir < 0 {
+		return s.left
+	}
+	return s.right
+}
+
+// setHeld records the state of the move key for dir.
+func (s *autoShift) setHeld(dir int, down bool) {
+	if dir < 0 {
+		s.left = down
+	} else {
+		s.right = down
+	}
+}
+
+// applyHeld handles press, release and repeat events of the keys that
+// auto-repeat: left, right and soft drop. A press acts immediately and starts
+// charging DAS; the most recently pressed direction wins while both are held.
+func (e *Engine) applyHeld(ev InputEvent) {
+	s := &e.shift
+	if ev.Input == InputSoftDrop {
+		switch ev.Kind {
+		case InputPressed, InputRepeated:
+			if ev.Kind == InputRepeated && s.down {
+				return
+			}
+			s.down, s.downFrames, s.downFresh = true, 0, true
+			e.softDropStep()
+		case InputReleased:
+			s.down = false
+		}
+		return
+	}
+
+	dir := 1
+	if ev.Input == InputLeft {
+		dir = -1
+	}
+	switch ev.Kind {
+	case InputPressed:
+		s.setHeld(dir, true)
+		s.dir, s.das, s.arr, s.fresh = dir, 0, 0, true
+		if e.Active() {
+			e.MovePiece(dir, 0)
+		}
+	case InputRepeated:
+		if s.held(dir) {
+			return
+		}
+		// the key has been down since before its first repeat: DAS is charged
+		s.setHeld(dir, true)
+		s.dir, s.das, s.arr, s.fresh = dir, e.DAS, 0, true
+		e.shiftStep(dir)
+	case InputReleased:
+		s.setHeld(dir, false)
+		if s.dir == dir {
+			s.dir = 0
+			if s.held(-dir) {
+				s.dir, s.das, s.arr = -dir, 0, 0
+			}
+		}
+	}
+}
+
+// autoShift repeats the held keys for the current frame. Once a move key has
+// been held for DAS frames the piece moves, then again every ARR frames.
+// DAS keeps charging during the line clear and entry delays.
+func (e *Engine) autoShift() {
+	s := &e.shift
+	switch {
+	case s.dir == 0 || s.fresh:
+	case s.das < e.DAS:
+		s.das++
+		if s.das == e.DAS {
+			s.arr = 0
+			e.shiftStep(s.dir)
+		}
+	default:
+		s.arr++
+		if s.arr >= e.ARR {
+			s.arr = 0
+			e.shiftStep(s.dir)
+		}
+	}
+	s.fresh = false
+
+	if s.down && !s.downFresh {
+		s.downFrames++
+		if s.downFrames >= e.SoftDropRate {
+			s.downFrames = 0
+			e.softDropStep()
+		}
+	}
+	s.downFresh = false
+}
+
+// shiftStep performs one auto-repeated move. With ARR 0 the piece slides all
+// the way to the wall or stack. Nothing moves while no piece is in play.
+func (e *Engine) shiftStep(dir int) {
+	if !e.Active() {
+		return
+	}
+	if e.ARR > 0 {
+		e.MovePiece(dir, 0)
+		return
+	}
+	for {
+		x := e.Current.X
+		e.MovePiece(dir, 0)
+		if e.Current.X == x {
+			return
+		}
+	}
+}
+
+// softDropStep performs one soft drop repeat. With a zero soft drop rate the
+// piece drops all the way to the floor without locking.
+func (e *Engine) softDropStep() {
+	if !e.Active() {
+		return
+	}
+	if e.SoftDropRate > 0 {
+		e.SoftDrop()
+		return
+	}
+	for !e.Grounded() {
+		e.SoftDrop()
+	}
+}
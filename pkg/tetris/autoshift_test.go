@@ -0,0 +1,76 @@
+package tetris
+
+import "testing"
+
+// shifted queues in before the first frame, steps frames frames on a 20
+// column board and returns how many columns the piece moved.
+func shifted(das, arr int, kind InputKind, frames int) int {
+	rules := DefaultRules()
+	rules.Width = 20
+	rules.DAS, rules.ARR = das, arr
+	e := NewEngine(rules, NewBagGenerator(1))
+	x := e.Current.X
+	e.queue = append(e.queue, InputEvent{InputRight, kind})
+	for range frames {
+		e.Step()
+	}
+	return e.Current.X - x
+}
+
+func TestAutoShift(t *testing.T) {
+	tests := []struct {
+		name   string
+		das    int
+		arr    int
+		kind   InputKind
+		frames int
+		want   int
+	}{
+		{"press", 10, 2, InputPressed, 1, 1},
+		{"charging", 10, 2, InputPressed, 10, 1},
+		{"charged", 10, 2, InputPressed, 11, 2},
+		{"first repeat", 10, 2, InputPressed, 13, 3},
+		{"repeating", 10, 2, InputPressed, 21, 7},
+		{"repeat holds with DAS charged", 10, 2, InputRepeated, 1, 1},
+		{"repeat then ARR", 10, 2, InputRepeated, 3, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shifted(tt.das, tt.arr, tt.kind, tt.frames); got != tt.want {
+				t.Errorf("moved %d columns after %d frames, want %d", got, tt.frames, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoShiftToWall(t *testing.T) {
+	rules := DefaultRules()
+	rules.ARR = 0
+	e := NewEngine(rules, NewBagGenerator(1))
+	e.Press(InputRight)
+	for range rules.DAS + 1 {
+		e.Step()
+	}
+	x := e.Current.X
+	if e.MovePiece(1, 0); e.Current.X != x {
+		t.Errorf("ARR 0 stopped at column %d, short of the wall", x)
+	}
+}
+
+func TestAutoShiftRelease(t *testing.T) {
+	rules := DefaultRules()
+	rules.Width = 20
+	e := NewEngine(rules, NewBagGenerator(1))
+	e.Press(InputRight)
+	for range 5 {
+		e.Step()
+	}
+	e.Release(InputRight)
+	x := e.Current.X
+	for range 30 {
+		e.Step()
+	}
+	if e.Current.X != x {
+		t.Errorf("piece moved %d columns after the release", e.Current.X-x)
+	}
+}
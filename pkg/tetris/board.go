@@ -1,6 +1,8 @@
 package tetris
 
 import (
+	"encoding/json"
+	"fmt"
 	"slices"
 
 	"github.com/gdamore/tcell/v2"
@@ -13,30 +15,81 @@ const (
 	BoardYOffset = 2  // Vertical offset for board display
 )
 
+// HiddenRows is the guideline buffer above the visible field. Pieces can be
+// moved, rotated and pushed into it, but only the visible rows are drawn.
+const HiddenRows = 20
+
+// Limits of the visible board size.
+const (
+	MinBoardWidth  = 4
+	MaxBoardWidth  = 40
+	MinBoardHeight = 8
+	MaxBoardHeight = 60
+)
+
 // Row represents a single row of the Tetris board, storing the color of each cell.
 type Row []tcell.Color
 
-// Board represents the Tetris playing field.
+// Board represents the Tetris playing field: the visible rows plus the hidden
+// buffer above them. Row indices count from the top of the buffer, so the
+// visible field starts at row Hidden().
 type Board struct {
-	grid []Row
+	grid          []Row
+	width, height int // Visible size
+	hidden        int // Buffer rows above the visible field
 }
 
 // NewBoard creates a new board with the standard Tetris dimensions (10x20).
 // All cells are initially empty (color 0).
 func NewBoard() *Board {
-	grid := make([]Row, BoardHeight)
+	return NewBoardSize(BoardWidth, BoardHeight)
+}
+
+// NewBoardSize creates an empty board with a visible field width cells wide
+// and height rows tall, clamped to the board size limits, and HiddenRows of
+// buffer above it.
+func NewBoardSize(width, height int) *Board {
+	width = min(max(width, MinBoardWidth), MaxBoardWidth)
+	height = min(max(height, MinBoardHeight), MaxBoardHeight)
+	grid := make([]Row, HiddenRows+height)
 	for i := range grid {
-		grid[i] = make(Row, BoardWidth)
+		grid[i] = make(Row, width)
 	}
 	return &Board{
-		grid: grid,
+		grid:   grid,
+		width:  width,
+		height: height,
+		hidden: HiddenRows,
 	}
 }
 
+// CheckBoardSize reports an error if width or height is outside the board size limits.
+func CheckBoardSize(width, height int) error {
+	if width < MinBoardWidth || width > MaxBoardWidth {
+		return fmt.Errorf("board width %d out of range %d-%d", width, MinBoardWidth, MaxBoardWidth)
+	}
+	if height < MinBoardHeight || height > MaxBoardHeight {
+		return fmt.Errorf("board height %d out of range %d-%d", height, MinBoardHeight, MaxBoardHeight)
+	}
+	return nil
+}
+
+// Width returns the number of columns.
+func (b *Board) Width() int { return b.width }
+
+// Height returns the number of visible rows.
+func (b *Board) Height() int { return b.height }
+
+// Hidden returns the number of buffer rows above the visible field.
+func (b *Board) Hidden() int { return b.hidden }
+
+// Rows returns the total number of rows, buffer included.
+func (b *Board) Rows() int { return len(b.grid) }
+
 // CellFilled checks if a cell at (row, col) is occupied (non-zero color).
 // Returns false for out-of-bounds queries.
 func (b *Board) CellFilled(row, col int) bool {
-	if row < 0 || row >= BoardHeight || col < 0 || col >= BoardWidth {
+	if row < 0 || row >= len(b.grid) || col < 0 || col >= b.width {
 		return false // out of bounds
 	}
 	return b.grid[row][col] != 0
@@ -45,7 +98,7 @@ func (b *Board) CellFilled(row, col int) bool {
 // SetCell places a colored block at the given (row, col) position.
 // Silently ignores out-of-bounds assignments.
 func (b *Board) SetCell(row, col int, value tcell.Color) {
-	if row >= 0 && row < BoardHeight && col >= 0 && col < BoardWidth {
+	if row >= 0 && row < len(b.grid) && col >= 0 && col < b.width {
 		b.grid[row][col] = tcell.Color(value)
 	}
 }
@@ -53,12 +106,29 @@ func (b *Board) SetCell(row, col int, value tcell.Color) {
 // Row returns the Row at the given index.
 // Returns nil for out-of-bounds indices.
 func (b *Board) Row(index int) Row {
-	if index < 0 || index >= BoardHeight {
+	if index < 0 || index >= len(b.grid) {
 		return nil
 	}
 	return b.grid[index]
 }
 
+// Fits reports whether piece p lies between the side walls and above the floor
+// without overlapping any filled cell. Rows above the buffer count as empty.
+func (b *Board) Fits(p Piece) bool {
+	for i, row := range p.Matrix {
+		for j, cell := range row {
+			if cell == 0 {
+				continue
+			}
+			if p.X+j < 0 || p.X+j >= b.width || p.Y+i >= len(b.grid) ||
+				b.CellFilled(p.Y+i, p.X+j) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // CellFilled checks if a cell in the row at the given column is occupied.
 // Returns false for out-of-bounds queries.
 func (r Row) CellFilled(col int) bool {
@@ -68,6 +138,40 @@ func (r Row) CellFilled(col int) bool {
 	return r[col] != 0
 }
 
+// Empty reports whether no cell of the board is filled.
+func (b *Board) Empty() bool {
+	for _, row := range b.grid {
+		for _, cell := range row {
+			if cell != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ClearsToEmpty reports whether clearing the completed rows leaves the board
+// empty, which makes the clear a perfect clear.
+func (b *Board) ClearsToEmpty() bool {
+	for _, row := range b.grid {
+		if slices.Contains(row, 0) && slices.ContainsFunc(row, func(c tcell.Color) bool { return c != 0 }) {
+			return false
+		}
+	}
+	return true
+}
+
+// FullRows returns the indices of all completely filled rows, top to bottom.
+func (b *Board) FullRows() []int {
+	var rows []int
+	for i, row := range b.grid {
+		if !slices.Contains(row, 0) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
 // ClearLines removes all completed (fully filled) rows from the board.
 // Completed rows are removed from the bottom up, and new empty rows are added at the top.
 // Returns the count of rows cleared.
@@ -79,7 +183,7 @@ func (b *Board) ClearLines() int {
 			// remove line
 			b.grid = append(b.grid[:i], b.grid[i+1:]...)
 			// add empty line at the top
-			newLine := make(Row, BoardWidth)
+			newLine := make(Row, b.width)
 			b.grid = append([]Row{newLine}, b.grid...)
 			// check same line again
 			i++
@@ -87,3 +191,62 @@ func (b *Board) ClearLines() int {
 	}
 	return linesCleared
 }
+
+// AddGarbage pushes the stack up by lines and fills the rows that appear at
+// the bottom with color, leaving column hole empty. Reports whether filled
+// cells were pushed out of the top of the buffer, which is a top out.
+func (b *Board) AddGarbage(lines, hole int, color tcell.Color) bool {
+	lines = min(lines, len(b.grid))
+	toppedOut := false
+	for _, row := range b.grid[:lines] {
+		if slices.ContainsFunc(row, func(c tcell.Color) bool { return c != 0 }) {
+			toppedOut = true
+		}
+	}
+	b.grid = b.grid[lines:]
+	for range lines {
+		row := make(Row, b.width)
+		for j := range row {
+			if j != hole {
+				row[j] = color
+			}
+		}
+		b.grid = append(b.grid, row)
+	}
+	return toppedOut
+}
+
+// boardState is the serialized form of a Board.
+type boardState struct {
+	Hidden int   `json:"hidden"`
+	Rows   []Row `json:"rows"`
+}
+
+// MarshalJSON encodes the board as its rows of cell colors, top of the buffer
+// first, which also records its size.
+func (b *Board) MarshalJSON() ([]byte, error) {
+	return json.Marshal(boardState{Hidden: b.hidden, Rows: b.grid})
+}
+
+// UnmarshalJSON restores a board encoded by MarshalJSON.
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var st boardState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	grid := st.Rows
+	if len(grid) == 0 || st.Hidden < 0 || st.Hidden >= len(grid) {
+		return fmt.Errorf("board has %d rows with %d hidden", len(grid), st.Hidden)
+	}
+	width := len(grid[0])
+	if err := CheckBoardSize(width, len(grid)-st.Hidden); err != nil {
+		return err
+	}
+	for i, row := range grid {
+		if len(row) != width {
+			return fmt.Errorf("board row %d has %d cells, want %d", i, len(row), width)
+		}
+	}
+	b.grid, b.width, b.height, b.hidden = grid, width, len(grid)-st.Hidden, st.Hidden
+	return nil
+}
@@ -0,0 +1,88 @@
+package tetris
+
+// RulesConfig is the serializable form of Rules: pluggable components are
+// stored by name and timings in frames. Rebuilding Rules from it always yields
+// fresh component state, e.g. a new back-to-back streak.
+type RulesConfig struct {
+	Rotation     string `json:"rotation"`
+	Scoring      string `json:"scoring"`
+	Gravity      string `json:"gravity"`
+	LockDelay    int    `json:"lock_delay"`
+	LockReset    string `json:"lock_reset"`
+	Allow180     bool   `json:"allow_180"`
+	DAS          int    `json:"das"`
+	ARR          int    `json:"arr"`
+	SoftDropRate int    `json:"soft_drop_rate"`
+	ARE          int    `json:"are"`
+	LineClear    int    `json:"line_clear"`
+	Width        int    `json:"width,omitempty"`  // Standard 10 columns when zero
+	Height       int    `json:"height,omitempty"` // Standard 20 rows when zero
+}
+
+// Config returns the serializable form of r. Nil components are recorded as
+// the defaults NewEngine would use.
+func (r Rules) Config() RulesConfig {
+	def := DefaultRules()
+	if r.Rotation == nil {
+		r.Rotation = def.Rotation
+	}
+	if r.Scoring == nil {
+		r.Scoring = def.Scoring
+	}
+	if r.Gravity == nil {
+		r.Gravity = def.Gravity
+	}
+	return RulesConfig{
+		Rotation:     r.Rotation.Name(),
+		Scoring:      r.Scoring.Name(),
+		Gravity:      r.Gravity.Name(),
+		LockDelay:    r.LockDelay,
+		LockReset:    r.LockReset.String(),
+		Allow180:     r.Allow180,
+		DAS:          r.DAS,
+		ARR:          r.ARR,
+		SoftDropRate: r.SoftDropRate,
+		ARE:          r.ARE,
+		LineClear:    r.LineClear,
+		Width:        r.Width,
+		Height:       r.Height,
+	}
+}
+
+// Rules rebuilds the rules described by c.
+func (c RulesConfig) Rules() (Rules, error) {
+	r := Rules{
+		LockDelay:    c.LockDelay,
+		Allow180:     c.Allow180,
+		DAS:          c.DAS,
+		ARR:          c.ARR,
+		SoftDropRate: c.SoftDropRate,
+		ARE:          c.ARE,
+		LineClear:    c.LineClear,
+		Width:        c.Width,
+		Height:       c.Height,
+	}
+	if r.Width == 0 {
+		r.Width = BoardWidth
+	}
+	if r.Height == 0 {
+		r.Height = BoardHeight
+	}
+	if err := CheckBoardSize(r.Width, r.Height); err != nil {
+		return Rules{}, err
+	}
+	var err error
+	if r.Rotation, err = NewRotationSystem(c.Rotation); err != nil {
+		return Rules{}, err
+	}
+	if r.Scoring, err = NewScoringRule(c.Scoring); err != nil {
+		return Rules{}, err
+	}
+	if r.Gravity, err = NewGravityCurve(c.Gravity); err != nil {
+		return Rules{}, err
+	}
+	if r.LockReset, err = ParseLockReset(c.LockReset); err != nil {
+		return Rules{}, err
+	}
+	return r, nil
+}
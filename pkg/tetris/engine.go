@@ -0,0 +1,718 @@
+package tetris
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Input is a player action the engine applies at the start of a frame.
+// Moves and soft drop repeat while held; the other inputs act once per press.
+type Input int
+
+const (
+	InputLeft      Input = iota // Move the piece one column left
+	InputRight                  // Move the piece one column right
+	InputSoftDrop               // Move the piece one row down
+	InputHardDrop               // Drop and lock the piece
+	InputRotateCW               // Rotate clockwise
+	InputRotateCCW              // Rotate counter-clockwise
+	InputRotate180              // Rotate by 180°
+	InputHold                   // Swap the piece with the hold slot
+	InputLevelUp                // Raise the level manually
+	InputLevelDown              // Lower the level manually
+
+	NumInputs = iota // Number of distinct inputs
+)
+
+// Held reports whether in auto-repeats while its key is held.
+func (in Input) Held() bool {
+	return in == InputLeft || in == InputRight || in == InputSoftDrop
+}
+
+// InputKind tells what happened to the key behind an input.
+type InputKind int
+
+const (
+	InputPressed  InputKind = iota // The key went down
+	InputReleased                  // The key went up
+	InputRepeated                  // The key is auto-repeating, so it has been held for a while
+)
+
+// InputEvent is an input queued for the next frame.
+type InputEvent struct {
+	Input Input
+	Kind  InputKind
+}
+
+// Rules selects how a game is played. All timings are counted in frames.
+type Rules struct {
+	Rotation     RotationSystem // Rotation system
+	Scoring      ScoringRule    // Scoring rule
+	Gravity      GravityCurve   // Gravity curve
+	LockDelay    int            // Frames a grounded piece may move before locking, 0 locks on contact
+	LockReset    LockReset      // Which actions restart the lock delay
+	Allow180     bool           // Enables 180° rotation
+	DAS          int            // Delayed Auto Shift: frames a move key is held before it repeats
+	ARR          int            // Auto Repeat Rate: frames between repeated moves, 0 shifts to the wall
+	SoftDropRate int            // Frames between rows while soft drop is held, 0 drops to the floor
+	ARE          int            // Entry delay: frames between a lock and the next spawn
+	LineClear    int            // Frames the line clear animation runs before rows collapse
+	Width        int            // Board columns, MinBoardWidth to MaxBoardWidth
+	Height       int            // Board rows, MinBoardHeight to MaxBoardHeight
+}
+
+// Default timings in frames.
+const (
+	DefaultLockDelay    = FrameRate / 2 // 500ms guideline lock delay
+	DefaultDAS          = 10            // ~167ms
+	DefaultARR          = 2             // ~33ms
+	DefaultSoftDropRate = 1             // one row per frame
+)
+
+// DefaultRules returns the guideline rules: SRS, guideline scoring and gravity,
+// and a 500ms move-reset lock delay.
+func DefaultRules() Rules {
+	return Rules{
+		Rotation:     SRS{},
+		Scoring:      &Guideline{},
+		Gravity:      GuidelineGravity{},
+		LockDelay:    DefaultLockDelay,
+		LockReset:    LockResetMove,
+		Allow180:     true,
+		DAS:          DefaultDAS,
+		ARR:          DefaultARR,
+		SoftDropRate: DefaultSoftDropRate,
+		Width:        BoardWidth,
+		Height:       BoardHeight,
+	}
+}
+
+// Engine is the fixed-timestep game simulation. Inputs are queued with Press,
+// Release and Repeat and applied at the start of the next Step, which advances
+// the game by exactly one frame at FrameRate. Gravity, the lock delay and auto
+// shift are counted in frames, so the same inputs on the same frames always
+// produce the same game.
+type Engine struct {
+	Board        *Board
+	Current      Piece
+	Next         Piece
+	Hold         *Piece // Held piece, nil until the first hold
+	HoldUsed     bool   // Set once the hold slot was used for the current drop
+	Allow180     bool   // Enables 180° rotation
+	DAS          int    // Frames a move key is held before it auto-repeats
+	ARR          int    // Frames between auto-repeated moves, 0 shifts to the wall
+	SoftDropRate int    // Frames between rows while soft drop is held, 0 drops to the floor
+	ARE          int    // Frames between a lock and the next spawn
+	LineClear    int    // Frames the line clear animation runs before rows collapse
+	Rotation     RotationSystem
+	Lock         LockDelay
+	Scoring      ScoringRule
+	Gravity      GravityCurve
+	LastLock     LockResult // Outcome of the most recent lock
+	LastClear    Clear      // Most recent scored clear, shown on the HUD
+	ClearUntil   int        // Frame at which the HUD stops showing LastClear
+	Frame        int        // Frames simulated so far
+	Lines        int
+	Score        int
+	Level        Level
+	GameOver     bool
+	EndReason    EndReason // Why the game ended, set along with GameOver
+	TetrisRate   *TetrisRate
+	Generator    Randomizer
+
+	queue       []InputEvent // Inputs waiting for the next frame
+	phase       phase        // What the engine is doing this frame
+	phaseLeft   int          // Frames left in a delay phase
+	shift       autoShift    // Held movement keys
+	lastRotate  bool         // The last successful move of the current piece was a rotation
+	lastKick    int          // Kick test used by that rotation, -1 for a 180° turn
+	fall        float64      // Accumulated gravity not yet applied, in cells
+	recording   *Replay      // Replay receiving applied inputs, if any
+	subscribers []subscriber // Receivers of engine events, see Subscribe
+	lastSub     int          // Id of the latest subscriber
+}
+
+// NewEngine creates a game on an empty board played by rules, drawing pieces
+// from gen. Nil rule components fall back to the guideline defaults and a
+// zero board size to the standard 10x20.
+func NewEngine(rules Rules, gen Randomizer) *Engine {
+	def := DefaultRules()
+	if rules.Width == 0 {
+		rules.Width = def.Width
+	}
+	if rules.Height == 0 {
+		rules.Height = def.Height
+	}
+	if rules.Rotation == nil {
+		rules.Rotation = def.Rotation
+	}
+	if rules.Scoring == nil {
+		rules.Scoring = def.Scoring
+	}
+	if rules.Gravity == nil {
+		rules.Gravity = def.Gravity
+	}
+	e := &Engine{
+		Board:        NewBoardSize(rules.Width, rules.Height),
+		Level:        Level{Number: 1},
+		TetrisRate:   &TetrisRate{},
+		Generator:    gen,
+		Allow180:     rules.Allow180,
+		DAS:          rules.DAS,
+		ARR:          rules.ARR,
+		SoftDropRate: rules.SoftDropRate,
+		ARE:          rules.ARE,
+		LineClear:    rules.LineClear,
+		Rotation:     rules.Rotation,
+		Scoring:      rules.Scoring,
+		Gravity:      rules.Gravity,
+		Lock: LockDelay{
+			Delay:     rules.LockDelay,
+			Mode:      rules.LockReset,
+			MaxResets: DefaultMaxLockResets,
+		},
+	}
+	e.Current = e.spawnPiece(e.Generator.Next())
+	e.Next = e.spawnPiece(e.Generator.Next())
+	e.Lock.Spawn(e.Current.Y)
+	return e
+}
+
+// spawnPiece returns piece id as the rotation system spawns it at the top of
+// the visible field, shifted so it stays centered on boards wider or narrower
+// than the standard ten columns.
+func (e *Engine) spawnPiece(id int) Piece {
+	p := e.Rotation.Spawn(id)
+	p.X += (e.Board.Width() - BoardWidth) / 2
+	p.Y += e.Board.Hidden()
+	return p
+}
+
+// phase is a state of the engine's per-piece cycle.
+type phase int
+
+const (
+	phaseFalling   phase = iota // A piece is in play
+	phaseLineClear              // Completed rows are animating before they collapse
+	phaseARE                    // Waiting for the next piece to spawn
+)
+
+// Active reports whether a piece is in play. It is false during the line
+// clear and entry delays, when Current must not be shown.
+func (e *Engine) Active() bool {
+	return e.phase == phaseFalling
+}
+
+// Clearing returns the rows being cleared during the line clear delay and how
+// far the animation has progressed, from 0 to 1. Returns nil outside the delay.
+func (e *Engine) Clearing() ([]int, float64) {
+	if e.phase != phaseLineClear {
+		return nil, 0
+	}
+	return e.LastLock.Rows, 1 - float64(e.phaseLeft)/float64(e.LineClear)
+}
+
+// Preview returns the next n pieces in spawn orientation, starting with Next.
+func (e *Engine) Preview(n int) []Piece {
+	if n <= 0 {
+		return nil
+	}
+	out := []Piece{e.Next}
+	for _, id := range e.Generator.Peek(n - 1) {
+		out = append(out, e.spawnPiece(id))
+	}
+	return out
+}
+
+// Press queues a key press of in for the next frame.
+func (e *Engine) Press(in Input) {
+	e.queue = append(e.queue, InputEvent{in, InputPressed})
+}
+
+// Release queues the release of a held key for the next frame.
+func (e *Engine) Release(in Input) {
+	e.queue = append(e.queue, InputEvent{in, InputReleased})
+}
+
+// Repeat queues an auto-repeat of in for the next frame. It is meant for input
+// sources that cannot report presses and releases reliably: a repeat of a key
+// that is not held yet holds it with DAS already charged.
+func (e *Engine) Repeat(in Input) {
+	e.queue = append(e.queue, InputEvent{in, InputRepeated})
+}
+
+// Step advances the game by one frame: queued inputs are applied in order,
+// held keys auto-repeat, then gravity and the lock delay run. During the line
+// clear and entry delays the frame only counts down the delay while held keys
+// keep charging; completed rows collapse when the line clear delay ends.
+// The frame stops as soon as the game ends.
+func (e *Engine) Step() {
+	if e.GameOver {
+		return
+	}
+	e.Frame++
+	start := e.phase
+	applied := e.queue
+	for i, ev := range e.queue {
+		e.apply(ev)
+		if e.GameOver {
+			// inputs after the one that ended the game are dropped
+			applied = e.queue[:i+1]
+			break
+		}
+	}
+	if r := e.recording; r != nil {
+		for _, ev := range applied {
+			r.Inputs = append(r.Inputs, FrameInput{e.Frame, ev})
+		}
+		r.Frames = e.Frame
+	}
+	e.queue = e.queue[:0]
+	if e.GameOver {
+		return
+	}
+	e.autoShift()
+	if e.GameOver {
+		return
+	}
+
+	switch start {
+	case phaseLineClear:
+		if e.phaseLeft--; e.phaseLeft <= 0 {
+			e.phase = phaseFalling
+			e.ClearLines()
+			e.enterARE()
+		}
+		return
+	case phaseARE:
+		if e.phaseLeft--; e.phaseLeft <= 0 {
+			e.spawnNext()
+		}
+		return
+	}
+
+	if e.Active() {
+		e.ApplyGravity()
+	}
+	if e.Active() && !e.GameOver {
+		e.Lock.Tick()
+		if e.LockDue() {
+			e.LockPiece()
+		}
+	}
+}
+
+// apply performs a single input event on the current piece.
+// Releases and repeats only matter for the keys that can be held.
+func (e *Engine) apply(ev InputEvent) {
+	if ev.Input.Held() {
+		e.applyHeld(ev)
+		return
+	}
+	if ev.Kind != InputPressed {
+		return
+	}
+	if !e.Active() && ev.Input != InputLevelUp && ev.Input != InputLevelDown {
+		return
+	}
+	switch ev.Input {
+	case InputHardDrop:
+		e.HardDrop()
+	case InputRotateCW:
+		e.RotatePiece(RotateCW)
+	case InputRotateCCW:
+		e.RotatePiece(RotateCCW)
+	case InputRotate180:
+		e.RotatePiece(Rotate180)
+	case InputHold:
+		e.HoldPiece()
+	case InputLevelUp:
+		e.IncreaseLevel()
+	case InputLevelDown:
+		e.DecreaseLevel()
+	}
+}
+
+// ApplyGravity advances gravity by one frame. Fractions of a cell accumulate
+// between frames and every whole cell moves the current piece one row down, so
+// gravity above one cell per frame drops several rows at once.
+// A grounded piece stops falling and goes through the lock delay instead.
+func (e *Engine) ApplyGravity() {
+	g := e.Gravity.Gravity(e.Level.Number)
+	if g >= MaxGravity {
+		// 20G crosses the whole board, however tall, in one frame
+		g = float64(e.Board.Rows())
+	}
+	e.fall += g
+	for e.fall >= 1 {
+		e.fall--
+		if e.Grounded() {
+			e.fall = 0
+			e.MovePiece(0, 1)
+			return
+		}
+		e.MovePiece(0, 1)
+	}
+}
+
+// MovePiece attempts to move the current piece by dx (horizontal) and dy (vertical) pixels.
+// If a downward move hits the floor (fitFloor), the lock delay starts and the piece
+// is locked once it expires. If the move is impossible (fitImpossible), it is reverted.
+func (e *Engine) MovePiece(dx, dy int) {
+	e.Current.X += dx
+	e.Current.Y += dy
+
+	// collision detection and handling would go here
+	if fit := e.Fit(); fit != fitPossible {
+		// revert move
+		e.Current.X -= dx
+		e.Current.Y -= dy
+		if dy > 0 && fit == fitFloor {
+			e.Lock.Update(e.Current.Y, true)
+			if e.Lock.Expired(true) {
+				e.LockPiece()
+			}
+		}
+		return
+	}
+	e.lastRotate = false
+	if dx != 0 {
+		e.Lock.Moved(e.Current.Y, e.Grounded())
+	} else {
+		e.Lock.Update(e.Current.Y, e.Grounded())
+	}
+	e.emit(PieceMoved{e.Current, dx, dy})
+}
+
+// Grounded reports whether the current piece rests on the floor or the stack.
+func (e *Engine) Grounded() bool {
+	below := e.Current
+	below.Y++
+	return e.fitPiece(below) != fitPossible
+}
+
+// LockDue reports whether the current piece has rested on the stack for the
+// whole lock delay and must be locked.
+func (e *Engine) LockDue() bool {
+	grounded := e.Grounded()
+	e.Lock.Update(e.Current.Y, grounded)
+	return e.Lock.Expired(grounded)
+}
+
+const (
+	fitImpossible = iota // Piece cannot fit (collision with board edge or existing blocks)
+	fitPossible          // Piece fits in the current position
+	fitFloor             // Piece has reached the floor and should be locked
+)
+
+// Fit checks if the current piece can fit at its current position on the board.
+// Returns one of: fitPossible, fitFloor (hit bottom), or fitImpossible (collision).
+func (e *Engine) Fit() int {
+	return e.fitPiece(e.Current)
+}
+
+// fitPiece applies the Fit collision rules to an arbitrary piece.
+func (e *Engine) fitPiece(p Piece) int {
+	for i, row := range p.Matrix {
+		for j, cell := range row {
+			if cell != 0 {
+				if p.X+j < 0 || p.X+j >= e.Board.Width() {
+					return fitImpossible
+				}
+				if p.Y+i >= e.Board.Rows() ||
+					e.Board.CellFilled(p.Y+i, p.X+j) {
+					return fitFloor
+				}
+			}
+		}
+	}
+	return fitPossible
+}
+
+// Ghost returns a copy of the current piece moved down to where it would land.
+func (e *Engine) Ghost() Piece {
+	ghost := e.Current
+	for {
+		ghost.Y++
+		if e.fitPiece(ghost) != fitPossible {
+			ghost.Y--
+			return ghost
+		}
+	}
+}
+
+// LockResult describes what a lock did to the board and the score.
+type LockResult struct {
+	Piece   Piece // The piece as it locked
+	Cells   int   // Cells written to the board
+	Rows    []int // Completed rows, counted from the top of the buffer
+	Clear   Clear // The lock as the scoring rule classified it
+	Points  int   // Points awarded for the clear, drop points not included
+	LockOut bool  // The piece locked entirely above the visible field
+}
+
+// LockPiece finalizes the current piece by placing it on the board and
+// scores the lines it completed. Completed rows collapse right away, or after
+// the line clear delay while they animate; then the entry delay starts.
+// Without delays the next piece spawns right away and game over is checked.
+// The result is also kept in LastLock.
+func (e *Engine) LockPiece() LockResult {
+	spin := DetectTSpin(e.Current, e.Board, e.lastRotate, e.lastKick)
+	e.lastRotate = false
+	res := LockResult{Piece: e.Current}
+	for i, row := range e.Current.Matrix {
+		for j, cell := range row {
+			if cell != 0 {
+				e.Board.SetCell(e.Current.Y+i, e.Current.X+j, e.Current.Color)
+				res.Cells++
+			}
+		}
+	}
+	if e.lockedOut() {
+		res.LockOut = true
+		e.LastLock = res
+		e.emit(PieceLocked{res})
+		e.end(LockOut)
+		return res
+	}
+	res.Rows = e.Board.FullRows()
+	lines := len(res.Rows)
+	res.Clear, res.Points = e.Scoring.Award(lines, spin, lines > 0 && e.Board.ClearsToEmpty(), e.Level.Number)
+	e.LastLock = res
+	e.UpdateScore(res)
+	e.emit(PieceLocked{res})
+	if lines > 0 && e.LineClear > 0 {
+		e.phase = phaseLineClear
+		e.phaseLeft = e.LineClear
+		return res
+	}
+	e.ClearLines()
+	e.enterARE()
+	return res
+}
+
+// ClearLines removes the completed rows of the last lock from the board and
+// returns the number of rows cleared.
+func (e *Engine) ClearLines() int {
+	lines := e.Board.ClearLines()
+	if lines > 0 {
+		e.emit(LinesCleared{e.LastLock.Rows, e.LastLock.Clear})
+	}
+	return lines
+}
+
+// lockedOut reports a lock out: the current piece lies entirely in the hidden
+// buffer above the visible field.
+func (e *Engine) lockedOut() bool {
+	for i, row := range e.Current.Matrix {
+		for _, cell := range row {
+			if cell != 0 && e.Current.Y+i >= e.Board.Hidden() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// garbageColor is the color of garbage rows.
+const garbageColor = tcell.ColorGray
+
+// AddGarbage pushes lines garbage rows with an empty cell in column hole up
+// from the bottom of the board. The falling piece is pushed up along with the
+// stack. Pushing blocks out of the top of the hidden buffer is a top out.
+func (e *Engine) AddGarbage(lines, hole int) {
+	if e.Board.AddGarbage(lines, hole, garbageColor) {
+		e.end(TopOut)
+		return
+	}
+	if !e.Active() {
+		return
+	}
+	for !e.Board.Fits(e.Current) && e.Current.Y > 0 {
+		e.Current.Y--
+	}
+	if !e.Board.Fits(e.Current) {
+		e.end(TopOut)
+	}
+}
+
+// enterARE starts the entry delay, or spawns the next piece when there is none.
+func (e *Engine) enterARE() {
+	if e.ARE > 0 {
+		e.phase = phaseARE
+		e.phaseLeft = e.ARE
+		return
+	}
+	e.spawnNext()
+}
+
+// spawnNext brings the next piece into play and checks for game over.
+func (e *Engine) spawnNext() {
+	e.phase = phaseFalling
+	e.fall = 0
+	e.Current = e.Next
+	e.HoldUsed = false
+	e.Lock.Spawn(e.Current.Y)
+	e.Next = e.spawnPiece(e.Generator.Next())
+	e.emit(PieceSpawned{e.Current})
+	if e.blockedOut() {
+		e.end(BlockOut)
+	}
+}
+
+// HoldPiece swaps the current piece with the one in the hold slot.
+// On the first hold the next piece is brought in instead. The held piece always
+// respawns in the spawn orientation of the rotation system.
+// Only one swap is allowed until the next LockPiece.
+func (e *Engine) HoldPiece() {
+	if e.HoldUsed {
+		return
+	}
+	held := e.spawnPiece(e.Current.ID)
+	if e.Hold == nil {
+		e.Current = e.Next
+		e.Next = e.spawnPiece(e.Generator.Next())
+	} else {
+		e.Current = *e.Hold
+	}
+	e.Hold = &held
+	e.HoldUsed = true
+	e.lastRotate = false
+	e.Lock.Spawn(e.Current.Y)
+	e.emit(PieceHeld{held, e.Current})
+	if e.blockedOut() {
+		e.end(BlockOut)
+	}
+}
+
+// HardDrop instantly drops the current piece to the bottom of the board and locks it,
+// awarding hard drop points for every cell dropped.
+func (e *Engine) HardDrop() {
+	ghost := e.Ghost()
+	e.Score += e.Scoring.DropPoints(ghost.Y-e.Current.Y, true)
+	if ghost.Y > e.Current.Y {
+		// the piece fell after its last rotation, so it cannot lock as a spin
+		e.lastRotate = false
+	}
+	e.Current = ghost
+	e.LockPiece()
+}
+
+// SoftDrop moves the current piece one row down, awarding soft drop points if it moved.
+func (e *Engine) SoftDrop() {
+	below := e.Current
+	below.Y++
+	if e.fitPiece(below) == fitPossible {
+		e.Score += e.Scoring.DropPoints(1, false)
+	}
+	e.MovePiece(0, 1)
+}
+
+// RotatePiece rotates the current piece by dir (RotateCW, RotateCCW
+// or Rotate180) following the configured rotation system.
+// If the rotation system finds no position that fits, the piece is left unchanged.
+func (e *Engine) RotatePiece(dir int) {
+	if dir == Rotate180 && !e.Allow180 {
+		return
+	}
+	if rotated, kick, ok := e.Rotation.Rotate(e.Current, dir, e.Board); ok {
+		e.Current = rotated
+		e.lastRotate = true
+		e.lastKick = kick
+		if dir == Rotate180 {
+			// the 180° kick table has no 1x2 kick, so it never promotes a mini
+			e.lastKick = -1
+		}
+		e.Lock.Moved(e.Current.Y, e.Grounded())
+		e.emit(PieceRotated{e.Current, dir, kick})
+	}
+}
+
+// IncreaseLevel increments the level by 1 and marks it as manually set.
+// Returns true if the level successfully increased.
+func (e *Engine) IncreaseLevel() bool {
+	if !e.Level.Set(e.Level.Number+1, true) {
+		return false
+	}
+	e.emit(LevelUp{e.Level.Number, true})
+	return true
+}
+
+// DecreaseLevel decrements the level by 1 (minimum 1) and marks it as manually set.
+// Returns true if the level successfully decreased, false if already at minimum.
+func (e *Engine) DecreaseLevel() bool {
+	if e.Level.Number > 1 {
+		return e.Level.Set(e.Level.Number-1, true)
+	}
+	return false
+}
+
+// clearShowFrames is how long the HUD announces a scored clear.
+const clearShowFrames = 90
+
+// UpdateScore adds the points of a lock to the score and its lines to the
+// line counter, tetris rate and level. Announceable clears are recorded for the HUD.
+func (e *Engine) UpdateScore(res LockResult) {
+	e.Score += res.Points
+	if res.Clear.Name() != "" {
+		e.LastClear = res.Clear
+		e.ClearUntil = e.Frame + clearShowFrames
+	}
+	if lines := len(res.Rows); lines > 0 {
+		e.TetrisRate.AddTetraLines(lines)
+		e.Lines += lines
+		e.UpdateLevel()
+	}
+}
+
+// UpdateLevel automatically increases the level based on score, unless manually overridden.
+// Level increases by 1 for every 10 lines cleared.
+// Returns true if the level changed, false otherwise.
+func (e *Engine) UpdateLevel() bool {
+	newLevel := e.Lines/10 + 1
+	up := newLevel > e.Level.Number
+	if !e.Level.Set(newLevel, false) {
+		return false
+	}
+	if up {
+		e.emit(LevelUp{e.Level.Number, false})
+	}
+	return true
+}
+
+// EndReason tells which rule ended a game.
+type EndReason int
+
+const (
+	NotOver  EndReason = iota // The game is still running
+	BlockOut                  // A new piece overlapped the stack when it spawned
+	LockOut                   // A piece locked entirely above the visible field
+	TopOut                    // Garbage pushed the stack out of the top of the board
+)
+
+var endReasonNames = [...]string{"", "block out", "lock out", "top out"}
+
+// String returns the reason as shown on the game-over screen, empty for NotOver.
+func (r EndReason) String() string {
+	if r < 0 || int(r) >= len(endReasonNames) {
+		return fmt.Sprintf("EndReason(%d)", int(r))
+	}
+	return endReasonNames[r]
+}
+
+// end finishes the game for reason. A game only ends once.
+func (e *Engine) end(reason EndReason) {
+	if e.GameOver {
+		return
+	}
+	e.GameOver = true
+	e.EndReason = reason
+	e.emit(GameEnded{reason})
+}
+
+// blockedOut reports a block out: every cell of the spawned piece must be
+// free and inside the board, otherwise it spawned into the stack.
+func (e *Engine) blockedOut() bool {
+	return !e.Board.Fits(e.Current)
+}
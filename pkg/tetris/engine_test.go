@@ -0,0 +1,163 @@
+package tetris
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHoldPiece(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	first, next := e.Current.ID, e.Next.ID
+	e.RotatePiece(RotateCW)
+	e.HoldPiece()
+	if e.Hold == nil || e.Hold.ID != first || e.Current.ID != next {
+		t.Fatalf("first hold: current %d, hold %v; want %d, %d", e.Current.ID, e.Hold, next, first)
+	}
+	if want := e.spawnPiece(first); e.Hold.Rotation != 0 || e.Hold.X != want.X || e.Hold.Y != want.Y {
+		t.Errorf("held piece at %d,%d rotation %d, want its spawn position", e.Hold.X, e.Hold.Y, e.Hold.Rotation)
+	}
+
+	e.HoldPiece()
+	if e.Current.ID != next || e.Hold.ID != first {
+		t.Error("held twice in one drop")
+	}
+
+	e.HardDrop()
+	if e.HoldUsed {
+		t.Fatal("hold still used after the lock")
+	}
+	second := e.Current.ID
+	e.HoldPiece()
+	if e.Current.ID != first || e.Hold.ID != second {
+		t.Errorf("swap: current %d, hold %d; want %d, %d", e.Current.ID, e.Hold.ID, first, second)
+	}
+}
+
+// filled returns the number of filled cells on b.
+func filled(b *Board) int {
+	n := 0
+	for r := range b.Rows() {
+		for c := range b.Width() {
+			if b.CellFilled(r, c) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestHardDrop(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	dropped := e.Ghost().Y - e.Current.Y
+	next := e.Next.ID
+	e.Press(InputHardDrop)
+	e.Step()
+	if n := filled(e.Board); n != 4 {
+		t.Fatalf("%d cells on the board after the drop, want 4", n)
+	}
+	if e.Current.ID != next {
+		t.Errorf("piece in play is %d, want the next piece %d", e.Current.ID, next)
+	}
+	floor := false
+	for c := range e.Board.Width() {
+		floor = floor || e.Board.CellFilled(e.Board.Rows()-1, c)
+	}
+	if !floor {
+		t.Error("piece did not lock on the floor")
+	}
+	if want := 2 * dropped; e.Score != want {
+		t.Errorf("score %d after a %d row drop, want %d", e.Score, dropped, want)
+	}
+}
+
+func TestLineClearAndARE(t *testing.T) {
+	const clear, are = 10, 5
+	rules := DefaultRules()
+	rules.LineClear, rules.ARE = clear, are
+	e := NewEngine(rules, NewBagGenerator(1))
+	e.Board = fillCells(bottomRow(0, 3, 4, 5, 6)...)
+	e.Current = e.spawnPiece(PieceI)
+	next := e.Next.ID
+	e.HardDrop()
+
+	for f := 1; f <= clear+are; f++ {
+		rows, progress := e.Clearing()
+		switch {
+		case f <= clear:
+			want := float64(f-1) / clear
+			if len(rows) != 1 || rows[0] != e.Board.Rows()-1 || math.Abs(progress-want) > 1e-9 {
+				t.Fatalf("frame %d: clearing %v at %v, want the bottom row at %v", f, rows, progress, want)
+			}
+			if filled(e.Board) != BoardWidth {
+				t.Fatalf("frame %d: rows collapsed before the line clear delay ended", f)
+			}
+		case rows != nil:
+			t.Fatalf("frame %d: still clearing during the entry delay", f)
+		case filled(e.Board) != 0:
+			t.Fatalf("frame %d: rows did not collapse after the line clear delay", f)
+		}
+		if e.Active() {
+			t.Fatalf("frame %d: piece in play during the delays", f)
+		}
+		e.Step()
+	}
+	if !e.Active() || e.Current.ID != next {
+		t.Errorf("piece %d in play %v after the delays, want %d", e.Current.ID, e.Active(), next)
+	}
+}
+
+func TestGarbage(t *testing.T) {
+	t.Run("pushes the piece into the buffer", func(t *testing.T) {
+		e := NewEngine(DefaultRules(), NewBagGenerator(1))
+		e.AddGarbage(BoardHeight, 0)
+		if e.GameOver {
+			t.Fatalf("game ended by %v", e.EndReason)
+		}
+		if !e.Board.Fits(e.Current) || e.Current.Y >= e.Board.Hidden() {
+			t.Errorf("piece at row %d, want it above the garbage in the buffer", e.Current.Y)
+		}
+	})
+	t.Run("no room for the piece", func(t *testing.T) {
+		e := NewEngine(DefaultRules(), NewBagGenerator(1))
+		e.AddGarbage(e.Board.Rows()-1, 0)
+		if e.EndReason != TopOut {
+			t.Errorf("end reason %v, want %v", e.EndReason, TopOut)
+		}
+	})
+	t.Run("blocks pushed out of the buffer", func(t *testing.T) {
+		e := NewEngine(DefaultRules(), NewBagGenerator(1))
+		e.Board.SetCell(0, 0, 1)
+		e.AddGarbage(1, 0)
+		if e.EndReason != TopOut {
+			t.Errorf("end reason %v, want %v", e.EndReason, TopOut)
+		}
+	})
+}
+
+func TestBlockOut(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	for c := range BoardWidth {
+		e.Board.SetCell(e.Board.Hidden(), c, 1)
+		e.Board.SetCell(e.Board.Hidden()+1, c, 1)
+	}
+	e.spawnNext()
+	if e.EndReason != BlockOut {
+		t.Errorf("end reason %v, want %v", e.EndReason, BlockOut)
+	}
+}
+
+func TestLockOut(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	// every visible row is full but for column 0
+	var cells [][2]int
+	for r := range BoardHeight {
+		cells = append(cells, bottomRow(r, 0)...)
+	}
+	e.Board = fillCells(cells...)
+	e.Current = e.spawnPiece(PieceO)
+	e.Current.Y = e.Board.Hidden() - len(e.Current.Matrix)
+	e.HardDrop()
+	if !e.LastLock.LockOut || e.EndReason != LockOut {
+		t.Errorf("lock out %v, end reason %v; want a lock out", e.LastLock.LockOut, e.EndReason)
+	}
+}
@@ -0,0 +1,94 @@
+package tetris
+
+import "slices"
+
+// Event is something that happened in the engine. Subscribers receive one of
+// the event types below and switch on it.
+type Event interface {
+	event()
+}
+
+// PieceSpawned is sent when a new piece comes into play from the next queue.
+type PieceSpawned struct {
+	Piece Piece
+}
+
+// PieceMoved is sent when the current piece moved by DX columns and DY rows,
+// by the player or by gravity.
+type PieceMoved struct {
+	Piece  Piece // The piece after the move
+	DX, DY int
+}
+
+// PieceRotated is sent when the current piece rotated.
+type PieceRotated struct {
+	Piece Piece // The piece after the rotation
+	Dir   int   // RotateCW, RotateCCW or Rotate180
+	Kick  int   // Kick test that made it fit, 0 for none
+}
+
+// PieceHeld is sent when the current piece went to the hold slot and Piece
+// came into play in its place.
+type PieceHeld struct {
+	Held  Piece // The piece now in the hold slot
+	Piece Piece // The piece now in play
+}
+
+// PieceLocked is sent when a piece locked, after the lock was scored.
+type PieceLocked struct {
+	LockResult
+}
+
+// LinesCleared is sent when completed rows collapse, which is after the line
+// clear delay when there is one.
+type LinesCleared struct {
+	Rows  []int // Rows removed, counted from the top of the buffer before the collapse
+	Clear Clear // The clear as the scoring rule classified it
+}
+
+// LevelUp is sent when the level rises, on its own or by the player.
+type LevelUp struct {
+	Level  int
+	Manual bool // Raised by the player rather than by cleared lines
+}
+
+// GameEnded is sent once when the game is over.
+type GameEnded struct {
+	Reason EndReason
+}
+
+func (PieceSpawned) event() {}
+func (PieceMoved) event()   {}
+func (PieceRotated) event() {}
+func (PieceHeld) event()    {}
+func (PieceLocked) event()  {}
+func (LinesCleared) event() {}
+func (LevelUp) event()      {}
+func (GameEnded) event()    {}
+
+// subscriber is a function registered with Subscribe.
+type subscriber struct {
+	id int
+	fn func(Event)
+}
+
+// Subscribe registers fn to receive every engine event, in the order they
+// happen, until the returned function is called. Events are delivered
+// synchronously while the engine runs, so fn must not block; it may read the
+// engine but must not change it. Subscribers are not part of a Snapshot.
+func (e *Engine) Subscribe(fn func(Event)) (unsubscribe func()) {
+	e.lastSub++
+	id := e.lastSub
+	e.subscribers = append(e.subscribers, subscriber{id, fn})
+	return func() {
+		// a copy, so an emit in progress keeps iterating the old list
+		e.subscribers = slices.DeleteFunc(slices.Clone(e.subscribers), func(s subscriber) bool { return s.id == id })
+	}
+}
+
+// emit delivers ev to every subscriber.
+func (e *Engine) emit(ev Event) {
+	for _, s := range e.subscribers {
+		s.fn(ev)
+	}
+}
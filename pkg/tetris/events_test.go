@@ -0,0 +1,82 @@
+package tetris
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// record subscribes to e and returns the type names of the events it sends.
+func record(e *Engine) *[]string {
+	var got []string
+	e.Subscribe(func(ev Event) {
+		got = append(got, fmt.Sprintf("%T", ev)[len("tetris."):])
+	})
+	return &got
+}
+
+func TestEventOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		lineClear int
+		frames    int
+		want      []string
+	}{
+		{"no delays", 0, 0, []string{"PieceLocked", "LinesCleared", "PieceSpawned"}},
+		{"during the line clear delay", 10, 5, []string{"PieceLocked"}},
+		{"after the line clear delay", 10, 10, []string{"PieceLocked", "LinesCleared", "PieceSpawned"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := DefaultRules()
+			rules.LineClear = tt.lineClear
+			e := NewEngine(rules, NewBagGenerator(1))
+			e.Board = fillCells(bottomRow(0, 3, 4, 5, 6)...)
+			e.Current = e.spawnPiece(PieceI)
+			got := record(e)
+			e.HardDrop()
+			for range tt.frames {
+				e.Step()
+			}
+			if !slices.Equal(*got, tt.want) {
+				t.Errorf("events %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameEndedOnce(t *testing.T) {
+	rules := DefaultRules()
+	rules.LockDelay = 0
+	e := NewEngine(rules, NewBagGenerator(1))
+	got := record(e)
+	for range 10000 {
+		e.Press(InputHardDrop)
+		e.Step()
+	}
+	if !e.GameOver {
+		t.Fatal("game did not end")
+	}
+	ended := 0
+	for _, ev := range *got {
+		if ev == "GameEnded" {
+			ended++
+		}
+	}
+	if ended != 1 || (*got)[len(*got)-1] != "GameEnded" {
+		t.Errorf("%d GameEnded events, last event %s; want one, last", ended, (*got)[len(*got)-1])
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	n := 0
+	unsubscribe := e.Subscribe(func(Event) { n++ })
+	e.HardDrop()
+	unsubscribe()
+	seen := n
+	e.HardDrop()
+	if seen == 0 || n != seen {
+		t.Errorf("%d events while subscribed, %d after", seen, n-seen)
+	}
+}
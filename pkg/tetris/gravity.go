@@ -0,0 +1,90 @@
+package tetris
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FrameRate is the number of gravity frames per second.
+const FrameRate = 60
+
+// MaxGravity is the gravity of 20G: a piece falls the whole board in one frame.
+// The engine drops pieces to the floor at this gravity, whatever the board height.
+const MaxGravity = 20.0
+
+// GravityCurve tells how fast pieces fall at a given level.
+type GravityCurve interface {
+	// Name returns the short identifier used to select the curve.
+	Name() string
+	// Gravity returns the falling speed in cells per frame at the given level (1-based).
+	// Values above 1 make pieces fall several cells per frame.
+	Gravity(level int) float64
+}
+
+// GravityCurves lists the names of all built-in gravity curves.
+var GravityCurves = []string{"guideline", "nes", "20g"}
+
+// NewGravityCurve returns the built-in gravity curve with the given name.
+func NewGravityCurve(name string) (GravityCurve, error) {
+	switch strings.ToLower(name) {
+	case "guideline":
+		return GuidelineGravity{}, nil
+	case "nes":
+		return NESGravity{}, nil
+	case "20g":
+		return TGM20G{}, nil
+	}
+	return nil, fmt.Errorf("unknown gravity curve %q (want one of %s)", name, strings.Join(GravityCurves, ", "))
+}
+
+// guidelineMaxLevel is the last level of the guideline formula, which already
+// reaches 20G. Past level 115 the formula is not even defined.
+const guidelineMaxLevel = 20
+
+// GuidelineGravity uses the guideline formula: a row takes
+// (0.8-((level-1)*0.007))^(level-1) seconds to fall. Levels above 20 fall
+// as fast as level 20.
+type GuidelineGravity struct{}
+
+// Name returns "guideline".
+func (GuidelineGravity) Name() string { return "guideline" }
+
+// Gravity converts the guideline seconds per row to cells per frame, capped at 20G.
+func (GuidelineGravity) Gravity(level int) float64 {
+	level = min(max(level, 1), guidelineMaxLevel)
+	secondsPerRow := math.Pow(0.8-float64(level-1)*0.007, float64(level-1))
+	return min(1/(secondsPerRow*FrameRate), MaxGravity)
+}
+
+// nesFramesPerCell holds the NTSC NES frames per cell for levels 0-28;
+// level 29 and above drop one cell every frame.
+var nesFramesPerCell = []int{
+	48, 43, 38, 33, 28, 23, 18, 13, 8, 6,
+	5, 5, 5, 4, 4, 4, 3, 3, 3, 2,
+	2, 2, 2, 2, 2, 2, 2, 2, 2,
+}
+
+// NESGravity follows the NES Tetris frame table. NES level 0 is level 1 here.
+type NESGravity struct{}
+
+// Name returns "nes".
+func (NESGravity) Name() string { return "nes" }
+
+// Gravity returns one cell per the number of frames the NES table gives for the level.
+func (NESGravity) Gravity(level int) float64 {
+	nesLevel := max(level-1, 0)
+	if nesLevel >= len(nesFramesPerCell) {
+		return 1
+	}
+	return 1 / float64(nesFramesPerCell[nesLevel])
+}
+
+// TGM20G drops pieces to the floor instantly at every level, like TGM's 20G modes.
+type TGM20G struct{}
+
+// Name returns "20g".
+func (TGM20G) Name() string { return "20g" }
+
+// Gravity always returns MaxGravity.
+func (TGM20G) Gravity(int) float64 { return MaxGravity }
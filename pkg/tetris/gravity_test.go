@@ -0,0 +1,76 @@
+package tetris
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGuidelineGravity(t *testing.T) {
+	tests := []struct {
+		level int
+		want  float64 // Cells per frame
+	}{
+		{1, 1.0 / 60},
+		{2, 1 / (0.793 * 60)},
+		{10, 1 / (math.Pow(0.737, 9) * 60)},
+		{20, MaxGravity},
+		{116, MaxGravity},
+		{1000, MaxGravity},
+	}
+	for _, tt := range tests {
+		if got := (GuidelineGravity{}).Gravity(tt.level); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("level %d: gravity %v, want %v", tt.level, got, tt.want)
+		}
+	}
+	prev := 0.0
+	for level := 1; level <= 200; level++ {
+		g := (GuidelineGravity{}).Gravity(level)
+		if g < prev || g > MaxGravity {
+			t.Fatalf("level %d: gravity %v after %v", level, g, prev)
+		}
+		prev = g
+	}
+}
+
+func TestNESGravity(t *testing.T) {
+	tests := []struct {
+		level int
+		want  float64
+	}{
+		{1, 1.0 / 48},
+		{10, 1.0 / 6},
+		{19, 1.0 / 3},
+		{29, 1.0 / 2},
+		{30, 1},
+		{99, 1},
+	}
+	for _, tt := range tests {
+		if got := (NESGravity{}).Gravity(tt.level); got != tt.want {
+			t.Errorf("level %d: gravity %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestGravityAtHighLevels(t *testing.T) {
+	for _, level := range []int{19, 20, 116, 117} {
+		e := NewEngine(DefaultRules(), NewBagGenerator(1))
+		e.Level.Set(level, true)
+		e.Step()
+		if !e.Grounded() {
+			t.Errorf("level %d: piece at row %d after one frame of 20G", level, e.Current.Y)
+		}
+	}
+}
+
+func Test20GOnTallBoards(t *testing.T) {
+	for _, height := range []int{MinBoardHeight, BoardHeight, MaxBoardHeight} {
+		rules := DefaultRules()
+		rules.Height = height
+		rules.Gravity = TGM20G{}
+		e := NewEngine(rules, NewBagGenerator(1))
+		e.Step()
+		if !e.Grounded() {
+			t.Errorf("height %d: piece at row %d of %d after one frame of 20G", height, e.Current.Y, e.Board.Rows())
+		}
+	}
+}
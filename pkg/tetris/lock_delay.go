@@ -0,0 +1,138 @@
+package tetris
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LockReset selects which player actions restart the lock delay timer.
+type LockReset int
+
+const (
+	LockResetMove LockReset = iota // Every move or rotation restarts the timer, up to MaxResets times
+	LockResetStep                  // Only reaching a new lowest row restarts the timer
+	LockResetNone                  // The timer starts on first floor contact and never restarts
+)
+
+// DefaultMaxLockResets is the guideline limit of move resets per piece.
+const DefaultMaxLockResets = 15
+
+var lockResetNames = []string{"move", "step", "none"}
+
+// String returns the short name of the reset mode.
+func (m LockReset) String() string {
+	if m < 0 || int(m) >= len(lockResetNames) {
+		return fmt.Sprintf("LockReset(%d)", int(m))
+	}
+	return lockResetNames[m]
+}
+
+// ParseLockReset converts a mode name ("move", "step" or "none") to a LockReset.
+func ParseLockReset(name string) (LockReset, error) {
+	for i, n := range lockResetNames {
+		if n == name {
+			return LockReset(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown lock reset mode %q (want move, step or none)", name)
+}
+
+// LockDelay tracks how many frames the current piece has been resting on the stack.
+// A zero Delay locks the piece as soon as it touches the floor.
+type LockDelay struct {
+	Delay     int       // Frames a grounded piece may stay unlocked
+	Mode      LockReset // Which actions restart the timer
+	MaxResets int       // Move resets allowed per piece in LockResetMove mode
+
+	active  bool // Timer is running
+	elapsed int  // Frames since the timer was last (re)started
+	resets  int  // Move resets used since the piece reached its lowest row
+	lowest  int  // Lowest row reached by the piece
+}
+
+// Frames converts a duration to the nearest whole number of frames.
+func Frames(d time.Duration) int {
+	return int((d*FrameRate + time.Second/2) / time.Second)
+}
+
+// Spawn prepares the timer for a freshly spawned piece at row y.
+func (l *LockDelay) Spawn(y int) {
+	l.active = false
+	l.resets = 0
+	l.lowest = y
+}
+
+// Update records the piece position after gravity or a move. y is the piece row
+// and grounded tells whether it rests on the floor or the stack.
+func (l *LockDelay) Update(y int, grounded bool) {
+	if y > l.lowest {
+		l.lowest = y
+		if l.Mode != LockResetNone {
+			l.resets = 0
+			l.active = false
+		}
+	}
+	switch {
+	case grounded && !l.active:
+		l.active = true
+		l.elapsed = 0
+	case !grounded && l.Mode == LockResetMove:
+		l.active = false
+	}
+}
+
+// Moved is called after a successful player move or rotation. In move reset
+// mode it restarts a running timer while resets remain.
+func (l *LockDelay) Moved(y int, grounded bool) {
+	if l.Mode == LockResetMove && l.active && l.resets < l.MaxResets {
+		l.resets++
+		l.elapsed = 0
+	}
+	l.Update(y, grounded)
+}
+
+// Expired reports whether a grounded piece must lock now.
+// Once all move resets are used up, a grounded piece locks immediately.
+func (l *LockDelay) Expired(grounded bool) bool {
+	if !grounded || !l.active {
+		return false
+	}
+	if l.Mode == LockResetMove && l.resets >= l.MaxResets {
+		return true
+	}
+	return l.elapsed >= l.Delay
+}
+
+// Tick advances a running timer by one frame.
+func (l *LockDelay) Tick() {
+	if l.active {
+		l.elapsed++
+	}
+}
+
+// lockDelayState is the serialized form of a LockDelay.
+type lockDelayState struct {
+	Delay     int       `json:"delay"`
+	Mode      LockReset `json:"mode"`
+	MaxResets int       `json:"max_resets"`
+	Active    bool      `json:"active"`
+	Elapsed   int       `json:"elapsed"`
+	Resets    int       `json:"resets"`
+	Lowest    int       `json:"lowest"`
+}
+
+// MarshalJSON encodes the settings together with the running timer.
+func (l LockDelay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lockDelayState{l.Delay, l.Mode, l.MaxResets, l.active, l.elapsed, l.resets, l.lowest})
+}
+
+// UnmarshalJSON restores a LockDelay encoded by MarshalJSON.
+func (l *LockDelay) UnmarshalJSON(data []byte) error {
+	var st lockDelayState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	*l = LockDelay{st.Delay, st.Mode, st.MaxResets, st.Active, st.Elapsed, st.Resets, st.Lowest}
+	return nil
+}
@@ -0,0 +1,83 @@
+package tetris
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrames(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want int
+	}{
+		{0, 0},
+		{17 * time.Millisecond, 1},
+		{33 * time.Millisecond, 2},
+		{167 * time.Millisecond, 10},
+		{500 * time.Millisecond, 30},
+		{time.Second, FrameRate},
+	}
+	for _, tt := range tests {
+		if got := Frames(tt.d); got != tt.want {
+			t.Errorf("Frames(%v) = %d, want %d", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestLockDelay(t *testing.T) {
+	const delay, maxResets = 30, 15
+	tests := []struct {
+		name   string
+		mode   LockReset
+		moves  int // Frames, from the first, that end with a successful move
+		stepAt int // Frame the piece steps down one row and lands again, 0 for never
+		want   int // Frame the piece locks on
+	}{
+		{"move/still", LockResetMove, 0, 0, delay},
+		{"move/resets", LockResetMove, 5, 0, 5 + delay},
+		{"move/reset limit", LockResetMove, 20, 0, maxResets},
+		{"move/new row renews resets", LockResetMove, 20, 10, 20 + delay},
+		{"move/reset limit after new row", LockResetMove, 40, 10, 10 + maxResets - 1},
+		{"step/moves ignored", LockResetStep, 5, 0, delay},
+		{"step/new row", LockResetStep, 0, 10, 10 + delay},
+		{"none/moves ignored", LockResetNone, 5, 0, delay},
+		{"none/new row ignored", LockResetNone, 0, 10, delay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := LockDelay{Delay: delay, Mode: tt.mode, MaxResets: maxResets}
+			y := 10
+			l.Spawn(0)
+			l.Update(y, true)
+			for f := 1; f <= 10*delay; f++ {
+				l.Tick()
+				if f == tt.stepAt {
+					y++
+					l.Update(y, true)
+				}
+				if f <= tt.moves {
+					l.Moved(y, true)
+				}
+				if l.Expired(true) {
+					if f != tt.want {
+						t.Errorf("locked on frame %d, want %d", f, tt.want)
+					}
+					return
+				}
+			}
+			t.Errorf("never locked, want frame %d", tt.want)
+		})
+	}
+}
+
+func TestLockDelayAirborne(t *testing.T) {
+	l := LockDelay{Delay: 1, Mode: LockResetMove, MaxResets: DefaultMaxLockResets}
+	l.Spawn(0)
+	l.Update(5, false)
+	for range 10 {
+		l.Tick()
+	}
+	if l.Expired(false) || l.Expired(true) {
+		t.Error("timer ran while the piece was in the air")
+	}
+}
@@ -0,0 +1,70 @@
+package tetris
+
+// NRS is the Nintendo Rotation System from NES Tetris.
+// T, J and L turn about their center block, I, S and Z only have two states,
+// nothing kicks and there is no 180° rotation.
+type NRS struct{}
+
+// nrsShapes holds the NRS rotation states indexed by piece ID.
+var nrsShapes = []shapeSet{
+	// I
+	{states: [][][]int{
+		{{0, 0, 0, 0}, {0, 0, 0, 0}, {Fill, Fill, Fill, Fill}, {0, 0, 0, 0}},
+		{{0, 0, Fill, 0}, {0, 0, Fill, 0}, {0, 0, Fill, 0}, {0, 0, Fill, 0}},
+	}, x: 3, y: -2},
+	// O
+	{states: [][][]int{
+		{{Fill, Fill}, {Fill, Fill}},
+	}, x: 4, y: 0},
+	// T
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, Fill}, {0, Fill, 0}},
+		{{0, Fill, 0}, {Fill, Fill, 0}, {0, Fill, 0}},
+		{{0, Fill, 0}, {Fill, Fill, Fill}, {0, 0, 0}},
+		{{0, Fill, 0}, {0, Fill, Fill}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// S
+	{states: [][][]int{
+		{{0, 0, 0}, {0, Fill, Fill}, {Fill, Fill, 0}},
+		{{0, Fill, 0}, {0, Fill, Fill}, {0, 0, Fill}},
+	}, x: 3, y: -1},
+	// Z
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, 0}, {0, Fill, Fill}},
+		{{0, 0, Fill}, {0, Fill, Fill}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// J
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, Fill}, {0, 0, Fill}},
+		{{0, Fill, 0}, {0, Fill, 0}, {Fill, Fill, 0}},
+		{{Fill, 0, 0}, {Fill, Fill, Fill}, {0, 0, 0}},
+		{{0, Fill, Fill}, {0, Fill, 0}, {0, Fill, 0}},
+	}, x: 3, y: -1},
+	// L
+	{states: [][][]int{
+		{{0, 0, 0}, {Fill, Fill, Fill}, {Fill, 0, 0}},
+		{{Fill, Fill, 0}, {0, Fill, 0}, {0, Fill, 0}},
+		{{0, 0, Fill}, {Fill, Fill, Fill}, {0, 0, 0}},
+		{{0, Fill, 0}, {0, Fill, 0}, {0, Fill, Fill}},
+	}, x: 3, y: -1},
+}
+
+// Name returns "nrs".
+func (NRS) Name() string { return "nrs" }
+
+// Spawn returns piece id in its NRS spawn orientation.
+func (NRS) Spawn(id int) Piece {
+	return nrsShapes[id].spawn(id)
+}
+
+// Rotate turns the piece in place and fails if the new state does not fit.
+func (NRS) Rotate(p Piece, dir int, b *Board) (Piece, int, bool) {
+	if dir != RotateCW && dir != RotateCCW {
+		return p, -1, false
+	}
+	rotated := nrsShapes[p.ID].rotate(p, dir)
+	if !b.Fits(rotated) {
+		return p, -1, false
+	}
+	return rotated, 0, true
+}
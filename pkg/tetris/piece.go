@@ -36,24 +36,46 @@ func SpawnPiece(n int) Piece {
 	}
 }
 
-// RotatePiece rotates the given piece 90 degrees clockwise.
-// Preserves the piece's position and color across rotations.
-func RotatePiece(p Piece) Piece {
-	n := len(p.Matrix)
-	m := len(p.Matrix[0])
-	newMatrix := make([][]int, m)
-	for i := range newMatrix {
-		newMatrix[i] = make([]int, n)
-	}
-	for i := 0; i < n; i++ {
-		for j := 0; j < m; j++ {
-			newMatrix[j][n-1-i] = p.Matrix[i][j]
-		}
+// Rotation directions, expressed as clockwise quarter turns.
+const (
+	RotateCW  = 1
+	Rotate180 = 2
+	RotateCCW = 3
+)
+
+// RotatePiece rotates the given piece by dir clockwise quarter turns (RotateCW,
+// Rotate180 or RotateCCW) inside its bounding box.
+// Preserves the piece's position, color and ID, and advances its rotation state.
+func RotatePiece(p Piece, dir int) Piece {
+	m := p.Matrix
+	for range (dir%4 + 4) % 4 {
+		m = rotateMatrixCW(m)
 	}
 	return Piece{
-		Matrix: newMatrix,
-		X:      p.X,
-		Y:      p.Y,
-		Color:  p.Color,
+		Matrix:   m,
+		X:        p.X,
+		Y:        p.Y,
+		Color:    p.Color,
+		ID:       p.ID,
+		Rotation: (p.Rotation + dir%4 + 4) % 4,
+	}
+}
+
+// rotateMatrixCW returns a new matrix representing m rotated 90 degrees clockwise.
+func rotateMatrixCW(m [][]int) [][]int {
+	n := len(m)
+	if n == 0 {
+		return [][]int{}
+	}
+	r := len(m[0])
+	newM := make([][]int, r)
+	for i := range newM {
+		newM[i] = make([]int, n)
+	}
+	for i := range n {
+		for j := range r {
+			newM[j][n-1-i] = m[i][j]
+		}
 	}
+	return newM
 }
@@ -3,15 +3,20 @@ package tetris
 import "github.com/gdamore/tcell/v2"
 
 // Pieces defines the seven standard Tetris tetrominoes (I, O, T, S, Z, J, L).
-// Each piece is defined with its initial matrix orientation, spawn position, and color.
+// Each piece is defined in its SRS spawn orientation inside the bounding box it
+// rotates in (4x4 for I, 3x3 for JLSTZ), so rotating the matrix pivots the piece
+// exactly like SRS does. Spawn positions place the top filled row on row 0.
 var Pieces = []Piece{
 	// I Piece
 	{
 		Matrix: [][]int{
+			{0, 0, 0, 0},
 			{Fill, Fill, Fill, Fill},
+			{0, 0, 0, 0},
+			{0, 0, 0, 0},
 		},
 		X:        3,
-		Y:        0,
+		Y:        -1,
 		Color:    tcell.Color(tcell.ColorAqua),
 		ID:       0,
 		Rotation: 0,
@@ -33,6 +38,7 @@ var Pieces = []Piece{
 		Matrix: [][]int{
 			{0, Fill, 0},
 			{Fill, Fill, Fill},
+			{0, 0, 0},
 		},
 		X:        3,
 		Y:        0,
@@ -45,6 +51,7 @@ var Pieces = []Piece{
 		Matrix: [][]int{
 			{0, Fill, Fill},
 			{Fill, Fill, 0},
+			{0, 0, 0},
 		},
 		X:        3,
 		Y:        0,
@@ -57,6 +64,7 @@ var Pieces = []Piece{
 		Matrix: [][]int{
 			{Fill, Fill, 0},
 			{0, Fill, Fill},
+			{0, 0, 0},
 		},
 		X:        3,
 		Y:        0,
@@ -69,6 +77,7 @@ var Pieces = []Piece{
 		Matrix: [][]int{
 			{Fill, 0, 0},
 			{Fill, Fill, Fill},
+			{0, 0, 0},
 		},
 		X:        3,
 		Y:        0,
@@ -81,6 +90,7 @@ var Pieces = []Piece{
 		Matrix: [][]int{
 			{0, 0, Fill},
 			{Fill, Fill, Fill},
+			{0, 0, 0},
 		},
 		X:        3,
 		Y:        0,
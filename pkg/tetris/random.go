@@ -0,0 +1,119 @@
+package tetris
+
+import (
+	"encoding/json"
+	"fmt"
+	rand "math/rand/v2"
+)
+
+// RandomGenerator picks every piece uniformly at random, independent of history.
+type RandomGenerator struct {
+	src *rand.PCG
+	rng *rand.Rand
+}
+
+// NewRandomGenerator creates a pure random generator with the given seed.
+func NewRandomGenerator(seed int64) *RandomGenerator {
+	src := newPCG(seed)
+	return &RandomGenerator{src: src, rng: rand.New(src)}
+}
+
+// Name returns "random".
+func (g *RandomGenerator) Name() string { return "random" }
+
+// Next returns a uniformly random piece index.
+func (g *RandomGenerator) Next() int { return g.rng.IntN(pieceCount) }
+
+// Peek returns the next n piece indices without advancing the sequence.
+func (g *RandomGenerator) Peek(n int) []int { return peek(g, n) }
+
+// Clone returns an independent generator continuing the same sequence.
+func (g *RandomGenerator) Clone() Randomizer {
+	src := clonePCG(g.src)
+	return &RandomGenerator{src: src, rng: rand.New(src)}
+}
+
+// MarshalBinary encodes the PCG state.
+func (g *RandomGenerator) MarshalBinary() ([]byte, error) {
+	return g.src.MarshalBinary()
+}
+
+// UnmarshalBinary restores a state produced by MarshalBinary.
+func (g *RandomGenerator) UnmarshalBinary(data []byte) error {
+	src := &rand.PCG{}
+	if err := src.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	g.src, g.rng = src, rand.New(src)
+	return nil
+}
+
+// NESGenerator reproduces NES Tetris: roll one of 8 outcomes and, if it is the
+// unused 8th or repeats the previous piece, roll once more among the 7 pieces.
+type NESGenerator struct {
+	prev int // Previous piece, -1 before the first
+	src  *rand.PCG
+	rng  *rand.Rand
+}
+
+// NewNESGenerator creates an NES style generator with the given seed.
+func NewNESGenerator(seed int64) *NESGenerator {
+	src := newPCG(seed)
+	return &NESGenerator{prev: -1, src: src, rng: rand.New(src)}
+}
+
+// Name returns "nes".
+func (g *NESGenerator) Name() string { return "nes" }
+
+// Next returns the next piece, rerolling once on a repeat.
+func (g *NESGenerator) Next() int {
+	p := g.rng.IntN(pieceCount + 1)
+	if p == pieceCount || p == g.prev {
+		p = g.rng.IntN(pieceCount)
+	}
+	g.prev = p
+	return p
+}
+
+// Peek returns the next n piece indices without advancing the sequence.
+func (g *NESGenerator) Peek(n int) []int { return peek(g, n) }
+
+// Clone returns an independent generator continuing the same sequence.
+func (g *NESGenerator) Clone() Randomizer {
+	src := clonePCG(g.src)
+	return &NESGenerator{prev: g.prev, src: src, rng: rand.New(src)}
+}
+
+// nesState is the serialized form of an NESGenerator.
+type nesState struct {
+	Prev int    `json:"prev"`
+	PCG  []byte `json:"pcg"`
+}
+
+// MarshalBinary encodes the previous piece and the PCG state.
+func (g *NESGenerator) MarshalBinary() ([]byte, error) {
+	pcg, err := g.src.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(nesState{Prev: g.prev, PCG: pcg})
+}
+
+// UnmarshalBinary restores a state produced by MarshalBinary.
+func (g *NESGenerator) UnmarshalBinary(data []byte) error {
+	var st nesState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	if st.Prev != -1 {
+		if err := checkPieces(st.Prev); err != nil {
+			return fmt.Errorf("nes randomizer state: %w", err)
+		}
+	}
+	src := &rand.PCG{}
+	if err := src.UnmarshalBinary(st.PCG); err != nil {
+		return err
+	}
+	g.prev, g.src, g.rng = st.Prev, src, rand.New(src)
+	return nil
+}
@@ -0,0 +1,83 @@
+package tetris
+
+import (
+	"encoding"
+	"fmt"
+	rand "math/rand/v2"
+	"strings"
+)
+
+// Randomizer produces the sequence of piece indices (0-6) for a game.
+// Implementations are deterministic for a given seed, and their full internal
+// state, including the PCG generator, round-trips through MarshalBinary and
+// UnmarshalBinary so a sequence can be resumed exactly.
+type Randomizer interface {
+	// Name returns the short identifier used to select the randomizer.
+	Name() string
+	// Next returns the next piece index and advances the sequence.
+	Next() int
+	// Peek returns the next n piece indices without advancing the sequence.
+	Peek(n int) []int
+	// Clone returns an independent copy that continues the same sequence.
+	Clone() Randomizer
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// Randomizers lists the names of all built-in randomizers.
+var Randomizers = []string{"7bag", "14bag", "random", "nes", "tgm1", "tgm2"}
+
+// NewRandomizer returns the built-in randomizer with the given name, seeded with seed.
+func NewRandomizer(name string, seed int64) (Randomizer, error) {
+	switch strings.ToLower(name) {
+	case "7bag":
+		return NewBagGenerator(seed), nil
+	case "14bag":
+		return NewMultiBagGenerator(seed, 2), nil
+	case "random":
+		return NewRandomGenerator(seed), nil
+	case "nes":
+		return NewNESGenerator(seed), nil
+	case "tgm1":
+		return NewTGMGenerator(seed, 4), nil
+	case "tgm2":
+		return NewTGMGenerator(seed, 6), nil
+	}
+	return nil, fmt.Errorf("unknown randomizer %q (want one of %s)", name, strings.Join(Randomizers, ", "))
+}
+
+// pieceCount is the number of distinct tetrominoes.
+const pieceCount = 7
+
+// checkPieces returns an error if any of ids is not a piece index, for
+// validating states read from a file.
+func checkPieces(ids ...int) error {
+	for _, id := range ids {
+		if id < 0 || id >= pieceCount {
+			return fmt.Errorf("invalid piece %d", id)
+		}
+	}
+	return nil
+}
+
+// newPCG creates the seeded PCG source shared by all randomizers.
+func newPCG(seed int64) *rand.PCG {
+	s := uint64(seed)
+	return rand.NewPCG(s, s^0x9e3779b97f4a7c15)
+}
+
+// clonePCG returns an independent copy of src in the same state.
+func clonePCG(src *rand.PCG) *rand.PCG {
+	c := *src
+	return &c
+}
+
+// peek returns the next n values of a clone of r, leaving r untouched.
+func peek(r Randomizer, n int) []int {
+	c := r.Clone()
+	out := make([]int, n)
+	for i := range out {
+		out[i] = c.Next()
+	}
+	return out
+}
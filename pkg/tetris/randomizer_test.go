@@ -0,0 +1,110 @@
+package tetris
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// draw returns the next n pieces of r.
+func draw(r Randomizer, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = r.Next()
+	}
+	return out
+}
+
+func TestRandomizerRoundTrip(t *testing.T) {
+	for _, name := range Randomizers {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewRandomizer(name, 42)
+			if err != nil {
+				t.Fatal(err)
+			}
+			draw(r, 11)
+
+			data, err := r.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			restored, _ := NewRandomizer(name, 0)
+			if err := restored.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			clone := r.Clone()
+
+			want := draw(r, 30)
+			if got := draw(restored, 30); !slices.Equal(got, want) {
+				t.Errorf("restored randomizer gave %v, want %v", got, want)
+			}
+			if got := draw(clone, 30); !slices.Equal(got, want) {
+				t.Errorf("clone gave %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRandomizerSeed(t *testing.T) {
+	for _, name := range Randomizers {
+		a, _ := NewRandomizer(name, 7)
+		b, _ := NewRandomizer(name, 7)
+		if x, y := draw(a, 50), draw(b, 50); !slices.Equal(x, y) {
+			t.Errorf("%s: same seed gave %v and %v", name, x, y)
+		}
+	}
+}
+
+func TestRandomizerPeek(t *testing.T) {
+	for _, name := range Randomizers {
+		r, _ := NewRandomizer(name, 5)
+		draw(r, 3)
+		peeked := r.Peek(20)
+		if again := r.Peek(20); !slices.Equal(peeked, again) {
+			t.Errorf("%s: Peek advanced the sequence: %v, then %v", name, peeked, again)
+		}
+		if got := draw(r, 20); !slices.Equal(got, peeked) {
+			t.Errorf("%s: Peek = %v, then Next gave %v", name, peeked, got)
+		}
+	}
+}
+
+func TestPreview(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(9))
+	preview := e.Preview(5)
+	for i, p := range preview {
+		if i > 0 {
+			e.spawnNext()
+		}
+		if e.Next.ID != p.ID {
+			t.Errorf("preview %d is piece %d, dealt %d", i, p.ID, e.Next.ID)
+		}
+	}
+}
+
+func TestRandomizerRejectsBadState(t *testing.T) {
+	pcg, _ := newPCG(1).MarshalBinary()
+	bag := func(st bagState) []byte {
+		st.PCG = pcg
+		data, _ := json.Marshal(st)
+		return data
+	}
+	nes, _ := json.Marshal(nesState{Prev: 7, PCG: pcg})
+	tgm, _ := json.Marshal(tgmState{Rolls: 4, History: [4]int{0, 1, 2, 9}, PCG: pcg})
+
+	tests := []struct {
+		name string
+		r    Randomizer
+		data []byte
+	}{
+		{"bag piece", NewBagGenerator(0), bag(bagState{Bag: []int{0, 1, 7}, Copies: 1})},
+		{"bag position", NewBagGenerator(0), bag(bagState{Bag: []int{0, 1, 2}, I: -1, Copies: 1})},
+		{"nes previous piece", NewNESGenerator(0), nes},
+		{"tgm history", NewTGMGenerator(0, 4), tgm},
+	}
+	for _, tt := range tests {
+		if err := tt.r.UnmarshalBinary(tt.data); err == nil {
+			t.Errorf("%s: bad state accepted", tt.name)
+		}
+	}
+}
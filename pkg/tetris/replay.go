@@ -0,0 +1,109 @@
+package tetris
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ReplayVersion is the replay format written by this version.
+const ReplayVersion = 1
+
+// Replay is a recorded game: everything that seeds the simulation plus every
+// input with the frame it was applied on. Since the engine is deterministic,
+// feeding the inputs back on the same frames reproduces the game exactly.
+type Replay struct {
+	Version    int          `json:"version"`
+	Seed       int64        `json:"seed"`
+	Randomizer string       `json:"randomizer"`
+	Rules      RulesConfig  `json:"rules"`
+	Frames     int          `json:"frames"` // Last frame of the game
+	Inputs     []FrameInput `json:"inputs"`
+}
+
+// FrameInput is an input event applied on a given frame.
+type FrameInput struct {
+	Frame int
+	InputEvent
+}
+
+// MarshalJSON encodes the input as a compact [frame, input, kind] triple.
+func (f FrameInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]int{f.Frame, int(f.Input), int(f.Kind)})
+}
+
+// UnmarshalJSON decodes a [frame, input, kind] triple.
+func (f *FrameInput) UnmarshalJSON(data []byte) error {
+	var v [3]int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v[1] < 0 || v[1] >= int(NumInputs) {
+		return fmt.Errorf("replay input %d out of range", v[1])
+	}
+	f.Frame, f.Input, f.Kind = v[0], Input(v[1]), InputKind(v[2])
+	return nil
+}
+
+// Write stores the replay as gzip-compressed JSON.
+func (r *Replay) Write(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(r); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// ReadReplay loads a replay stored by Write.
+func ReadReplay(rd io.Reader) (*Replay, error) {
+	zr, err := gzip.NewReader(rd)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var r Replay
+	if err := json.NewDecoder(zr).Decode(&r); err != nil {
+		return nil, err
+	}
+	if r.Version != ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+	return &r, nil
+}
+
+// Record starts logging every input the engine applies, and the frame count,
+// into r. Pass nil to stop recording.
+func (e *Engine) Record(r *Replay) {
+	e.recording = r
+}
+
+// ReplayPlayer re-simulates a Replay on an engine created with its seed and rules.
+type ReplayPlayer struct {
+	Replay *Replay
+	Engine *Engine
+	next   int // Index of the next input to feed
+}
+
+// NewReplayPlayer returns a player feeding r into e.
+func NewReplayPlayer(r *Replay, e *Engine) *ReplayPlayer {
+	return &ReplayPlayer{Replay: r, Engine: e}
+}
+
+// Done reports whether the replay has reached its last frame.
+func (p *ReplayPlayer) Done() bool {
+	return p.Engine.GameOver || p.Engine.Frame >= p.Replay.Frames
+}
+
+// Step queues the inputs recorded for the next frame and advances the engine
+// by that frame. Does nothing once the replay is done.
+func (p *ReplayPlayer) Step() {
+	if p.Done() {
+		return
+	}
+	frame := p.Engine.Frame + 1
+	for ; p.next < len(p.Replay.Inputs) && p.Replay.Inputs[p.next].Frame <= frame; p.next++ {
+		p.Engine.queue = append(p.Engine.queue, p.Replay.Inputs[p.next].InputEvent)
+	}
+	p.Engine.Step()
+}
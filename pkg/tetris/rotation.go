@@ -0,0 +1,107 @@
+package tetris
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RotationSystem owns the spawn orientation of every piece and the way pieces
+// turn and kick. Each competitive game has its own, so the Engine takes one
+// from its Rules instead of hard-coding the rules.
+type RotationSystem interface {
+	// Name returns the short identifier used to select the system.
+	Name() string
+	// Spawn returns piece id in its spawn orientation and position.
+	Spawn(id int) Piece
+	// Rotate turns p by dir (RotateCW, RotateCCW or Rotate180) on board b.
+	// Returns the rotated piece, the index of the kick test that succeeded
+	// (0 for an unshifted rotation) and whether the rotation was possible.
+	Rotate(p Piece, dir int, b *Board) (Piece, int, bool)
+}
+
+// RotationSystems lists the names of all built-in rotation systems.
+var RotationSystems = []string{"srs", "ars", "nrs", "classic"}
+
+// NewRotationSystem returns the built-in rotation system with the given name.
+func NewRotationSystem(name string) (RotationSystem, error) {
+	switch strings.ToLower(name) {
+	case "srs":
+		return SRS{}, nil
+	case "ars":
+		return ARS{}, nil
+	case "nrs":
+		return NRS{}, nil
+	case "classic":
+		return Classic{}, nil
+	}
+	return nil, fmt.Errorf("unknown rotation system %q (want one of %s)", name, strings.Join(RotationSystems, ", "))
+}
+
+// shapeSet describes a piece as a fixed list of rotation states, for systems
+// whose orientations are not pure rotations of a bounding box.
+type shapeSet struct {
+	states [][][]int // rotation states in clockwise order, starting with spawn
+	x, y   int       // spawn position
+}
+
+// spawn returns piece id in state 0 at the set's spawn position.
+func (s shapeSet) spawn(id int) Piece {
+	return Piece{
+		Matrix:   copyMatrix(s.states[0]),
+		X:        s.x,
+		Y:        s.y,
+		Color:    Pieces[id].Color,
+		ID:       id,
+		Rotation: 0,
+	}
+}
+
+// rotate returns p turned by dir in place. Pieces with fewer than four states
+// cycle through the ones they have.
+func (s shapeSet) rotate(p Piece, dir int) Piece {
+	p.Rotation = (p.Rotation + dir%4 + 4) % 4
+	p.Matrix = copyMatrix(s.states[p.Rotation%len(s.states)])
+	return p
+}
+
+// copyMatrix returns a deep copy of m.
+func copyMatrix(m [][]int) [][]int {
+	c := make([][]int, len(m))
+	for i := range m {
+		c[i] = make([]int, len(m[i]))
+		copy(c[i], m[i])
+	}
+	return c
+}
+
+// Classic is the naive rotation system: the piece matrix is rotated in place
+// and the rotation is refused if it does not fit. There are no kicks.
+type Classic struct{}
+
+// classicShapes are the compact piece matrices used before SRS was introduced.
+var classicShapes = [][][]int{
+	{{Fill, Fill, Fill, Fill}},
+	{{Fill, Fill}, {Fill, Fill}},
+	{{0, Fill, 0}, {Fill, Fill, Fill}},
+	{{0, Fill, Fill}, {Fill, Fill, 0}},
+	{{Fill, Fill, 0}, {0, Fill, Fill}},
+	{{Fill, 0, 0}, {Fill, Fill, Fill}},
+	{{0, 0, Fill}, {Fill, Fill, Fill}},
+}
+
+// Name returns "classic".
+func (Classic) Name() string { return "classic" }
+
+// Spawn returns the compact matrix of piece id at its template position.
+func (Classic) Spawn(id int) Piece {
+	return shapeSet{states: [][][]int{classicShapes[id]}, x: Pieces[id].X, y: 0}.spawn(id)
+}
+
+// Rotate turns the matrix in place and succeeds only if it fits unshifted.
+func (Classic) Rotate(p Piece, dir int, b *Board) (Piece, int, bool) {
+	rotated := RotatePiece(p, dir)
+	if !b.Fits(rotated) {
+		return p, -1, false
+	}
+	return rotated, 0, true
+}
@@ -0,0 +1,296 @@
+package tetris
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ScoringRule turns locks into points. Rules differ in base values, how the
+// level multiplies them and whether they know about spins, combos and drops,
+// so runs are only comparable under the same rule.
+type ScoringRule interface {
+	// Name returns the short identifier used to select the rule.
+	Name() string
+	// Award scores a lock that cleared lines rows at the given level and advances
+	// any combo state the rule keeps. Returns the clear as the rule sees it and its points.
+	Award(lines int, spin SpinType, perfect bool, level int) (Clear, int)
+	// DropPoints returns the points for dropping a piece cells rows by a soft or hard drop.
+	DropPoints(cells int, hard bool) int
+}
+
+// ScoringRules lists the names of all built-in scoring rules.
+var ScoringRules = []string{"guideline", "nes", "bps", "sega"}
+
+// NewScoringRule returns a fresh instance of the built-in scoring rule with the given name.
+func NewScoringRule(name string) (ScoringRule, error) {
+	switch strings.ToLower(name) {
+	case "guideline":
+		return &Guideline{}, nil
+	case "nes":
+		return NES{}, nil
+	case "bps":
+		return BPS{}, nil
+	case "sega":
+		return Sega{}, nil
+	}
+	return nil, fmt.Errorf("unknown scoring rule %q (want one of %s)", name, strings.Join(ScoringRules, ", "))
+}
+
+// SpinType classifies how a T piece was locked.
+type SpinType int
+
+const (
+	SpinNone SpinType = iota // Not a spin
+	SpinMini                 // T-spin mini
+	SpinFull                 // Proper T-spin
+)
+
+// Clear describes the outcome of a single lock for scoring and display.
+type Clear struct {
+	Lines      int      // Rows cleared by the lock
+	Spin       SpinType // T-spin classification of the lock
+	Perfect    bool     // The board was empty afterwards
+	Combo      int      // Consecutive clears before this one, 0 for the first
+	BackToBack bool     // A difficult clear following another difficult clear
+}
+
+// Difficult reports whether the clear keeps a back-to-back chain alive:
+// a tetris or any T-spin that clears lines.
+func (c Clear) Difficult() bool {
+	return c.Lines == 4 || (c.Spin != SpinNone && c.Lines > 0)
+}
+
+var clearNames = []string{"", "SINGLE", "DOUBLE", "TRIPLE", "TETRIS"}
+
+// Name returns the HUD label of the clear, e.g. "T-SPIN DOUBLE", "B2B TETRIS"
+// or "PERFECT CLEAR". Returns "" for a lock that is not worth announcing.
+func (c Clear) Name() string {
+	if c.Perfect {
+		return "PERFECT CLEAR"
+	}
+	var parts []string
+	if c.BackToBack {
+		parts = append(parts, "B2B")
+	}
+	switch c.Spin {
+	case SpinMini:
+		parts = append(parts, "T-SPIN MINI")
+	case SpinFull:
+		parts = append(parts, "T-SPIN")
+	}
+	if c.Lines > 0 && c.Lines < len(clearNames) {
+		parts = append(parts, clearNames[c.Lines])
+	}
+	return strings.Join(parts, " ")
+}
+
+// Name returns "guideline".
+func (s *Guideline) Name() string { return "guideline" }
+
+// DropPoints awards 1 point per cell soft dropped and 2 per cell hard dropped.
+func (s *Guideline) DropPoints(cells int, hard bool) int {
+	if hard {
+		return 2 * cells
+	}
+	return cells
+}
+
+// Guideline base points per lines cleared, multiplied by the level.
+var (
+	linePoints     = []int{0, 100, 300, 500, 800}
+	miniSpinPoints = []int{100, 200, 400}
+	spinPoints     = []int{400, 800, 1200, 1600}
+	perfectPoints  = []int{0, 800, 1200, 1800, 2000}
+)
+
+const (
+	comboPoints          = 50   // Per combo step, multiplied by the level
+	perfectB2BTetrisBase = 3200 // Perfect clear bonus for a back-to-back tetris
+)
+
+// Guideline implements modern guideline scoring with T-spins, combos, back-to-back
+// difficult clears and perfect clear bonuses, all multiplied by the level.
+// The zero value is ready to use.
+type Guideline struct {
+	streak int  // Consecutive locks that cleared lines
+	b2b    bool // The last line clear was difficult
+}
+
+// guidelineState is the serialized form of a Guideline.
+type guidelineState struct {
+	Streak int  `json:"streak"`
+	B2B    bool `json:"b2b"`
+}
+
+// MarshalJSON encodes the combo and back-to-back state.
+func (s *Guideline) MarshalJSON() ([]byte, error) {
+	return json.Marshal(guidelineState{s.streak, s.b2b})
+}
+
+// UnmarshalJSON restores a state encoded by MarshalJSON.
+func (s *Guideline) UnmarshalJSON(data []byte) error {
+	var st guidelineState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	s.streak, s.b2b = st.Streak, st.B2B
+	return nil
+}
+
+// Award scores a lock that cleared lines rows at the given level and advances
+// the combo and back-to-back state. Returns the classified clear and its points.
+func (s *Guideline) Award(lines int, spin SpinType, perfect bool, level int) (Clear, int) {
+	c := Clear{Lines: lines, Spin: spin, Perfect: perfect}
+	points := basePoints(lines, spin)
+	if lines == 0 {
+		// a lock without lines breaks the combo but not the back-to-back chain
+		s.streak = 0
+		return c, points * level
+	}
+
+	s.streak++
+	c.Combo = s.streak - 1
+	if c.Difficult() {
+		if s.b2b {
+			c.BackToBack = true
+			points = points * 3 / 2
+		}
+		s.b2b = true
+	} else {
+		s.b2b = false
+	}
+	points += comboPoints * c.Combo
+	if perfect {
+		bonus := perfectPoints[min(lines, 4)]
+		if lines == 4 && c.BackToBack {
+			bonus = perfectB2BTetrisBase
+		}
+		points += bonus
+	}
+	return c, points * level
+}
+
+// classicPoints are the line clear values shared by the NES and BPS rules.
+var classicPoints = []int{0, 40, 100, 300, 1200}
+
+// NES scores line clears like NES Tetris: 40/100/300/1200 multiplied by the
+// level (NES level 0 is level 1 here). Soft drops score 1 point per cell.
+type NES struct{}
+
+// Name returns "nes".
+func (NES) Name() string { return "nes" }
+
+// Award scores the lines cleared; spins, combos and perfect clears are ignored.
+func (NES) Award(lines int, _ SpinType, _ bool, level int) (Clear, int) {
+	return Clear{Lines: lines}, classicPoints[min(lines, 4)] * level
+}
+
+// DropPoints awards 1 point per cell soft dropped. NES has no hard drop.
+func (NES) DropPoints(cells int, hard bool) int {
+	if hard {
+		return 0
+	}
+	return cells
+}
+
+// BPS scores line clears like the Blue Planet Software games:
+// 40/100/300/1200 regardless of level, and nothing for drops.
+type BPS struct{}
+
+// Name returns "bps".
+func (BPS) Name() string { return "bps" }
+
+// Award scores the lines cleared without a level multiplier.
+func (BPS) Award(lines int, _ SpinType, _ bool, _ int) (Clear, int) {
+	return Clear{Lines: lines}, classicPoints[min(lines, 4)]
+}
+
+// DropPoints always returns 0.
+func (BPS) DropPoints(int, bool) int { return 0 }
+
+// segaPoints are the level 1 and 2 values of the Sega rule.
+var segaPoints = []int{0, 100, 400, 900, 2000}
+
+// segaMaxMultiplier caps the level multiplier of the Sega rule.
+const segaMaxMultiplier = 5
+
+// Sega scores line clears like Sega Tetris: 100/400/900/2000, multiplied by 2
+// from level 3, by 3 from level 5 and so on up to 5 from level 9, and nothing
+// for drops.
+type Sega struct{}
+
+// Name returns "sega".
+func (Sega) Name() string { return "sega" }
+
+// Award scores the lines cleared with a multiplier that rises every two levels.
+func (Sega) Award(lines int, _ SpinType, _ bool, level int) (Clear, int) {
+	return Clear{Lines: lines}, segaPoints[min(lines, 4)] * min((max(level, 1)-1)/2+1, segaMaxMultiplier)
+}
+
+// DropPoints always returns 0.
+func (Sega) DropPoints(int, bool) int { return 0 }
+
+// basePoints returns the level 1 points for a clear before bonuses.
+func basePoints(lines int, spin SpinType) int {
+	switch spin {
+	case SpinMini:
+		return miniSpinPoints[min(lines, len(miniSpinPoints)-1)]
+	case SpinFull:
+		return spinPoints[min(lines, len(spinPoints)-1)]
+	}
+	return linePoints[min(lines, len(linePoints)-1)]
+}
+
+// tSpinPromoteKick is the index of the last SRS kick test of a 90° rotation,
+// whose 1x2 shift turns a mini into a full T-spin.
+const tSpinPromoteKick = 4
+
+// DetectTSpin applies the 3-corner rule to a T piece about to lock on board b.
+// lastRotate tells whether the last successful move was a rotation, and kick is
+// the index of the kick test it used. A spin is a T-spin if both corners on the
+// pointing side are occupied, otherwise a mini; a 90° SRS rotation that used
+// the last kick test is always promoted to a full T-spin. Pass a kick of -1 for
+// 180° rotations, which are never promoted.
+func DetectTSpin(p Piece, b *Board, lastRotate bool, kick int) SpinType {
+	if p.ID != PieceT || !lastRotate || len(p.Matrix) != 3 || len(p.Matrix[0]) != 3 {
+		return SpinNone
+	}
+	occupied := func(row, col int) bool {
+		r, c := p.Y+row, p.X+col
+		if c < 0 || c >= b.Width() || r >= b.Rows() {
+			return true
+		}
+		return b.CellFilled(r, c)
+	}
+	corners := 0
+	for _, rc := range [][2]int{{0, 0}, {0, 2}, {2, 0}, {2, 2}} {
+		if occupied(rc[0], rc[1]) {
+			corners++
+		}
+	}
+	if corners < 3 {
+		return SpinNone
+	}
+
+	// front corners flank the cell the T points to
+	m := p.Matrix
+	var front [2][2]int
+	switch {
+	case m[0][1] != 0 && m[2][1] == 0: // pointing up
+		front = [2][2]int{{0, 0}, {0, 2}}
+	case m[1][2] != 0 && m[1][0] == 0: // pointing right
+		front = [2][2]int{{0, 2}, {2, 2}}
+	case m[2][1] != 0 && m[0][1] == 0: // pointing down
+		front = [2][2]int{{2, 0}, {2, 2}}
+	default: // pointing left
+		front = [2][2]int{{0, 0}, {2, 0}}
+	}
+	if occupied(front[0][0], front[0][1]) && occupied(front[1][0], front[1][1]) {
+		return SpinFull
+	}
+	if kick == tSpinPromoteKick {
+		return SpinFull
+	}
+	return SpinMini
+}
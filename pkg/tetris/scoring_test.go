@@ -0,0 +1,134 @@
+package tetris
+
+import "testing"
+
+var (
+	tUp   = [][]int{{0, Fill, 0}, {Fill, Fill, Fill}, {0, 0, 0}}
+	tDown = [][]int{{0, 0, 0}, {Fill, Fill, Fill}, {0, Fill, 0}}
+)
+
+// fillCells returns a 10x20 board with the given cells filled. Rows count up
+// from the bottom row, 0.
+func fillCells(cells ...[2]int) *Board {
+	b := NewBoardSize(BoardWidth, BoardHeight)
+	for _, rc := range cells {
+		b.SetCell(b.Rows()-1-rc[0], rc[1], 1)
+	}
+	return b
+}
+
+// bottomRow returns the cells of bottom row row, except the columns in holes.
+func bottomRow(row int, holes ...int) [][2]int {
+	var cells [][2]int
+	for c := range BoardWidth {
+		hole := false
+		for _, h := range holes {
+			hole = hole || c == h
+		}
+		if !hole {
+			cells = append(cells, [2]int{row, c})
+		}
+	}
+	return cells
+}
+
+func TestDetectTSpin(t *testing.T) {
+	b := NewBoardSize(BoardWidth, BoardHeight)
+	bottom := b.Rows() - 1
+	// a T pointing down into a one-cell hole in the bottom row, with an overhang
+	tsd := fillCells(append(bottomRow(0, 4), [2]int{2, 3})...)
+	// a T pointing up on the floor, with one cell on its left shoulder
+	mini := fillCells([2]int{1, 3})
+
+	tests := []struct {
+		name       string
+		id         int
+		matrix     [][]int
+		y          int
+		board      *Board
+		lastRotate bool
+		kick       int
+		want       SpinType
+	}{
+		{"full", PieceT, tDown, bottom - 2, tsd, true, 0, SpinFull},
+		{"not rotated", PieceT, tDown, bottom - 2, tsd, false, 0, SpinNone},
+		{"not a T", PieceJ, tDown, bottom - 2, tsd, true, 0, SpinNone},
+		{"mini", PieceT, tUp, bottom - 1, mini, true, 0, SpinMini},
+		{"mini promoted by the 1x2 kick", PieceT, tUp, bottom - 1, mini, true, tSpinPromoteKick, SpinFull},
+		{"mini after a 180° kick", PieceT, tUp, bottom - 1, mini, true, -1, SpinMini},
+		{"two corners", PieceT, tUp, bottom - 1, fillCells(), true, 0, SpinNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Piece{Matrix: tt.matrix, X: 3, Y: tt.y, ID: tt.id}
+			if got := DetectTSpin(p, tt.board, tt.lastRotate, tt.kick); got != tt.want {
+				t.Errorf("DetectTSpin = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHardDropIsNotASpin(t *testing.T) {
+	// the T rotated at spawn drops into a pocket whose corners would make a mini
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	e.Board = fillCells(append(bottomRow(0, 4), [2]int{2, 3})...)
+	e.Current = e.spawnPiece(PieceT)
+	e.RotatePiece(RotateCW)
+	e.HardDrop()
+	if spin := e.LastLock.Clear.Spin; spin != SpinNone {
+		t.Errorf("hard dropped T scored as %v", spin)
+	}
+}
+
+func TestGuidelineAward(t *testing.T) {
+	locks := []struct {
+		lines   int
+		spin    SpinType
+		perfect bool
+		want    int
+		b2b     bool
+		combo   int
+	}{
+		{4, SpinNone, false, 800, false, 0},
+		{4, SpinNone, false, 1200 + 50, true, 1},
+		{1, SpinNone, false, 100 + 100, false, 2},
+		{0, SpinNone, false, 0, false, 0},
+		{2, SpinFull, false, 1200, false, 0},
+		{0, SpinMini, false, 100, false, 0},
+		{1, SpinMini, false, 200 * 3 / 2, true, 0},
+		{2, SpinNone, true, 300 + 50 + 1200, false, 1},
+	}
+	var g Guideline
+	for i, l := range locks {
+		c, got := g.Award(l.lines, l.spin, l.perfect, 1)
+		if got != l.want || c.BackToBack != l.b2b || c.Combo != l.combo {
+			t.Errorf("lock %d: %d points, b2b %v, combo %d; want %d, %v, %d",
+				i, got, c.BackToBack, c.Combo, l.want, l.b2b, l.combo)
+		}
+	}
+}
+
+func TestClassicAward(t *testing.T) {
+	tests := []struct {
+		rule  ScoringRule
+		level int
+		want  [5]int // Points for 0 to 4 lines
+	}{
+		{NES{}, 1, [5]int{0, 40, 100, 300, 1200}},
+		{NES{}, 3, [5]int{0, 120, 300, 900, 3600}},
+		{BPS{}, 9, [5]int{0, 40, 100, 300, 1200}},
+		{Sega{}, 1, [5]int{0, 100, 400, 900, 2000}},
+		{Sega{}, 2, [5]int{0, 100, 400, 900, 2000}},
+		{Sega{}, 3, [5]int{0, 200, 800, 1800, 4000}},
+		{Sega{}, 6, [5]int{0, 300, 1200, 2700, 6000}},
+		{Sega{}, 9, [5]int{0, 500, 2000, 4500, 10000}},
+		{Sega{}, 20, [5]int{0, 500, 2000, 4500, 10000}},
+	}
+	for _, tt := range tests {
+		for lines, want := range tt.want {
+			if _, got := tt.rule.Award(lines, SpinNone, false, tt.level); got != want {
+				t.Errorf("%s level %d, %d lines: %d points, want %d", tt.rule.Name(), tt.level, lines, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package tetris
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Snapshot is the complete, serializable state of an Engine between frames.
+// Restoring it continues the game exactly where it was saved, including the
+// randomizer sequence, lock timer, scoring streaks and any running delay.
+// Held keys and queued inputs are not kept: a restored game starts with all
+// keys released.
+type Snapshot struct {
+	Rules           RulesConfig     `json:"rules"`
+	Randomizer      string          `json:"randomizer"`
+	RandomizerState []byte          `json:"randomizer_state"`
+	ScoringState    json.RawMessage `json:"scoring_state,omitempty"`
+	Board           *Board          `json:"board"`
+	Current         Piece           `json:"current"`
+	Next            Piece           `json:"next"`
+	Hold            *Piece          `json:"hold,omitempty"`
+	HoldUsed        bool            `json:"hold_used"`
+	Lock            LockDelay       `json:"lock"`
+	LastLock        LockResult      `json:"last_lock"`
+	LastClear       Clear           `json:"last_clear"`
+	ClearUntil      int             `json:"clear_until"`
+	Frame           int             `json:"frame"`
+	Lines           int             `json:"lines"`
+	Score           int             `json:"score"`
+	Level           Level           `json:"level"`
+	GameOver        bool            `json:"game_over"`
+	EndReason       EndReason       `json:"end_reason,omitempty"`
+	TetrisRate      TetrisRate      `json:"tetris_rate"`
+
+	Phase      int     `json:"phase"`
+	PhaseLeft  int     `json:"phase_left"`
+	LastRotate bool    `json:"last_rotate"`
+	LastKick   int     `json:"last_kick"`
+	Fall       float64 `json:"fall"`
+}
+
+// Snapshot captures the engine state. Components that keep state, such as
+// the randomizer and guideline scoring, are serialized along with it.
+func (e *Engine) Snapshot() (*Snapshot, error) {
+	gen, err := e.Generator.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var scoring json.RawMessage
+	if m, ok := e.Scoring.(json.Marshaler); ok {
+		if scoring, err = m.MarshalJSON(); err != nil {
+			return nil, err
+		}
+	}
+	return &Snapshot{
+		Rules:           e.Rules().Config(),
+		Randomizer:      e.Generator.Name(),
+		RandomizerState: gen,
+		ScoringState:    scoring,
+		Board:           e.Board,
+		Current:         e.Current,
+		Next:            e.Next,
+		Hold:            e.Hold,
+		HoldUsed:        e.HoldUsed,
+		Lock:            e.Lock,
+		LastLock:        e.LastLock,
+		LastClear:       e.LastClear,
+		ClearUntil:      e.ClearUntil,
+		Frame:           e.Frame,
+		Lines:           e.Lines,
+		Score:           e.Score,
+		Level:           e.Level,
+		GameOver:        e.GameOver,
+		EndReason:       e.EndReason,
+		TetrisRate:      *e.TetrisRate,
+		Phase:           int(e.phase),
+		PhaseLeft:       e.phaseLeft,
+		LastRotate:      e.lastRotate,
+		LastKick:        e.lastKick,
+		Fall:            e.fall,
+	}, nil
+}
+
+// Rules returns the rules the engine is played with.
+func (e *Engine) Rules() Rules {
+	return Rules{
+		Rotation:     e.Rotation,
+		Scoring:      e.Scoring,
+		Gravity:      e.Gravity,
+		LockDelay:    e.Lock.Delay,
+		LockReset:    e.Lock.Mode,
+		Allow180:     e.Allow180,
+		DAS:          e.DAS,
+		ARR:          e.ARR,
+		SoftDropRate: e.SoftDropRate,
+		ARE:          e.ARE,
+		LineClear:    e.LineClear,
+		Width:        e.Board.Width(),
+		Height:       e.Board.Height(),
+	}
+}
+
+// RestoreEngine rebuilds an engine from a snapshot taken by Engine.Snapshot.
+func RestoreEngine(s *Snapshot) (*Engine, error) {
+	if s.Board == nil {
+		return nil, fmt.Errorf("snapshot has no board")
+	}
+	pieces := []Piece{s.Current, s.Next}
+	if s.Hold != nil {
+		pieces = append(pieces, *s.Hold)
+	}
+	for _, p := range pieces {
+		if err := checkPieces(p.ID); err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+		if p.Rotation < 0 || p.Rotation > 3 {
+			return nil, fmt.Errorf("snapshot: invalid rotation %d of piece %d", p.Rotation, p.ID)
+		}
+	}
+	rules, err := s.Rules.Rules()
+	if err != nil {
+		return nil, err
+	}
+	switch phase(s.Phase) {
+	case phaseFalling, phaseARE:
+	case phaseLineClear:
+		if rules.LineClear <= 0 {
+			return nil, fmt.Errorf("snapshot: line clear phase without a line clear delay")
+		}
+	default:
+		return nil, fmt.Errorf("snapshot: invalid phase %d", s.Phase)
+	}
+	if len(s.ScoringState) > 0 {
+		u, ok := rules.Scoring.(json.Unmarshaler)
+		if !ok {
+			return nil, fmt.Errorf("scoring rule %s keeps no state", rules.Scoring.Name())
+		}
+		if err := u.UnmarshalJSON(s.ScoringState); err != nil {
+			return nil, err
+		}
+	}
+	gen, err := NewRandomizer(s.Randomizer, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// NewEngine deals the first pieces, so the randomizer state is restored after it
+	e := NewEngine(rules, gen)
+	if err := gen.UnmarshalBinary(s.RandomizerState); err != nil {
+		return nil, err
+	}
+	rate := s.TetrisRate
+	e.Board = s.Board
+	e.Current, e.Next, e.Hold, e.HoldUsed = s.Current, s.Next, s.Hold, s.HoldUsed
+	e.Lock = s.Lock
+	e.LastLock, e.LastClear, e.ClearUntil = s.LastLock, s.LastClear, s.ClearUntil
+	e.Frame, e.Lines, e.Score, e.Level = s.Frame, s.Lines, s.Score, s.Level
+	e.GameOver, e.EndReason, e.TetrisRate = s.GameOver, s.EndReason, &rate
+	e.phase, e.phaseLeft = phase(s.Phase), s.PhaseLeft
+	e.lastRotate, e.lastKick = s.LastRotate, s.LastKick
+	e.fall = s.Fall
+
+	// Keys are not held after a restore. Releasing them explicitly on the first
+	// frame keeps a replay recorded across the save in step with the game.
+	for in := Input(0); in < NumInputs; in++ {
+		if in.Held() {
+			e.Release(in)
+		}
+	}
+	return e, nil
+}
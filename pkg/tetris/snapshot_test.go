@@ -0,0 +1,95 @@
+package tetris
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// script presses one input every few frames and releases it on the next, so
+// no key is held across a frame divisible by 8.
+func script(e *Engine, frame int) {
+	inputs := []Input{InputLeft, InputRotateCW, InputRight, InputRight, InputRotateCCW, InputHold, InputHardDrop}
+	switch frame % 8 {
+	case 1:
+		e.Press(inputs[(frame/8)%len(inputs)])
+	case 2:
+		e.Release(inputs[(frame/8)%len(inputs)])
+	}
+}
+
+// roundTrip passes s through JSON like a save file does.
+func roundTrip(t *testing.T, s *Snapshot) *Snapshot {
+	t.Helper()
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out Snapshot
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	for _, name := range Randomizers {
+		t.Run(name, func(t *testing.T) {
+			rules := DefaultRules()
+			rules.ARE, rules.LineClear = 6, 12
+			gen, _ := NewRandomizer(name, 3)
+			e := NewEngine(rules, gen)
+			for f := 1; f <= 400; f++ {
+				script(e, f)
+				e.Step()
+			}
+			s, err := e.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := RestoreEngine(roundTrip(t, s))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for f := 401; f <= 1200; f++ {
+				script(e, f)
+				e.Step()
+				script(r, f)
+				r.Step()
+			}
+			if e.Frame != r.Frame || e.Score != r.Score || e.Lines != r.Lines || e.GameOver != r.GameOver {
+				t.Errorf("restored game at frame %d, score %d, %d lines, over %v; want %d, %d, %d, %v",
+					r.Frame, r.Score, r.Lines, r.GameOver, e.Frame, e.Score, e.Lines, e.GameOver)
+			}
+			if !reflect.DeepEqual(e.Board, r.Board) || !reflect.DeepEqual(e.Current, r.Current) {
+				t.Error("restored game has a different board or piece")
+			}
+		})
+	}
+}
+
+func TestRestoreRejectsBadState(t *testing.T) {
+	e := NewEngine(DefaultRules(), NewBagGenerator(1))
+	tests := []struct {
+		name  string
+		patch func(*Snapshot)
+	}{
+		{"current", func(s *Snapshot) { s.Current.ID = 7 }},
+		{"next", func(s *Snapshot) { s.Next.ID = -1 }},
+		{"hold", func(s *Snapshot) { h := s.Current; h.ID = 12; s.Hold = &h }},
+		{"rotation", func(s *Snapshot) { s.Current.Rotation = 4 }},
+		{"phase", func(s *Snapshot) { s.Phase = 7 }},
+		{"line clear phase without delay", func(s *Snapshot) { s.Phase = int(phaseLineClear) }},
+	}
+	for _, tt := range tests {
+		s, err := e.Snapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s = roundTrip(t, s)
+		tt.patch(s)
+		if _, err := RestoreEngine(s); err == nil {
+			t.Errorf("%s: bad snapshot accepted", tt.name)
+		}
+	}
+}
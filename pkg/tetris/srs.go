@@ -0,0 +1,107 @@
+package tetris
+
+// Piece indices used by the rotation rules.
+const (
+	PieceI = iota
+	PieceO
+	PieceT
+	PieceS
+	PieceZ
+	PieceJ
+	PieceL
+)
+
+// Kick is a single SRS kick offset. Y follows the SRS convention where positive
+// values point upwards, so it must be subtracted from the board row.
+type Kick struct {
+	X, Y int
+}
+
+// rotationKey identifies a transition between two rotation states (0, R, 2, L).
+type rotationKey struct {
+	from, to int
+}
+
+// kicksJLSTZ holds the SRS wall kick tests shared by the J, L, S, T and Z pieces.
+var kicksJLSTZ = map[rotationKey][]Kick{
+	{0, 1}: {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+	{1, 0}: {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+	{1, 2}: {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+	{2, 1}: {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+	{2, 3}: {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+	{3, 2}: {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	{3, 0}: {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	{0, 3}: {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+}
+
+// kicksI holds the SRS wall kick tests for the I piece.
+var kicksI = map[rotationKey][]Kick{
+	{0, 1}: {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}},
+	{1, 0}: {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}},
+	{1, 2}: {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}},
+	{2, 1}: {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}},
+	{2, 3}: {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}},
+	{3, 2}: {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}},
+	{3, 0}: {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}},
+	{0, 3}: {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}},
+}
+
+// kicks180 holds the tests for 180° rotations, which SRS itself does not define.
+// The values follow the widely used SRS+ extension.
+var kicks180 = map[rotationKey][]Kick{
+	{0, 2}: {{0, 0}, {0, 1}, {1, 1}, {-1, 1}, {1, 0}, {-1, 0}},
+	{2, 0}: {{0, 0}, {0, -1}, {-1, -1}, {1, -1}, {-1, 0}, {1, 0}},
+	{1, 3}: {{0, 0}, {1, 0}, {1, 2}, {1, 1}, {0, 2}, {0, 1}},
+	{3, 1}: {{0, 0}, {-1, 0}, {-1, 2}, {-1, 1}, {0, 2}, {0, 1}},
+}
+
+// noKicks is used by the O piece, which never moves when rotated.
+var noKicks = []Kick{{0, 0}}
+
+// SRSKicks returns the wall kick tests for rotating piece id from one rotation
+// state to another. The first test is always the unshifted position.
+func SRSKicks(id, from, to int) []Kick {
+	key := rotationKey{from, to}
+	if (from-to+4)%4 == 2 {
+		if id == PieceO {
+			return noKicks
+		}
+		return kicks180[key]
+	}
+	switch id {
+	case PieceO:
+		return noKicks
+	case PieceI:
+		return kicksI[key]
+	default:
+		return kicksJLSTZ[key]
+	}
+}
+
+// SRS is the Super Rotation System used by guideline games. Pieces rotate inside
+// their bounding box and try the (from, to) kick tests returned by SRSKicks.
+type SRS struct{}
+
+// Name returns "srs".
+func (SRS) Name() string { return "srs" }
+
+// Spawn returns piece id in its SRS spawn orientation.
+func (SRS) Spawn(id int) Piece {
+	return SpawnPiece(id)
+}
+
+// Rotate turns the piece and returns the first kick position that fits.
+// Kick offsets use the SRS convention where positive y is upwards; board Y
+// increases downward, so the y offset is subtracted from the piece row.
+func (SRS) Rotate(p Piece, dir int, b *Board) (Piece, int, bool) {
+	rotated := RotatePiece(p, dir)
+	for i, k := range SRSKicks(p.ID, p.Rotation, rotated.Rotation) {
+		try := rotated
+		try.X = p.X + k.X
+		try.Y = p.Y - k.Y
+		if b.Fits(try) {
+			return try, i, true
+		}
+	}
+	return p, -1, false
+}
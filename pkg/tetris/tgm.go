@@ -0,0 +1,121 @@
+package tetris
+
+import (
+	"encoding/json"
+	"fmt"
+	rand "math/rand/v2"
+)
+
+// TGMGenerator implements the TGM history randomizer: each piece is rolled up
+// to Rolls times until it is not among the last four pieces, keeping the last
+// roll otherwise. TGM1 uses 4 rolls with a history of Z,Z,Z,Z; TGM2 uses 6 rolls
+// with Z,S,S,Z. The first piece is never S, Z or O.
+type TGMGenerator struct {
+	rolls   int    // Attempts to avoid the history
+	history [4]int // Last four pieces, oldest first
+	first   bool   // The next piece is the first of the game
+	src     *rand.PCG
+	rng     *rand.Rand
+}
+
+// NewTGMGenerator creates a history-4 generator with the given seed and number
+// of rolls (4 for TGM1, 6 for TGM2).
+func NewTGMGenerator(seed int64, rolls int) *TGMGenerator {
+	src := newPCG(seed)
+	g := &TGMGenerator{rolls: max(rolls, 1), first: true, src: src, rng: rand.New(src)}
+	if g.rolls >= 6 {
+		g.history = [4]int{PieceZ, PieceS, PieceS, PieceZ}
+	} else {
+		g.history = [4]int{PieceZ, PieceZ, PieceZ, PieceZ}
+	}
+	return g
+}
+
+// Name returns "tgm1" or "tgm2".
+func (g *TGMGenerator) Name() string {
+	if g.rolls >= 6 {
+		return "tgm2"
+	}
+	return "tgm1"
+}
+
+// tgmFirstPieces are the pieces a TGM game may start with.
+var tgmFirstPieces = []int{PieceI, PieceT, PieceJ, PieceL}
+
+// Next returns the next piece and pushes it into the history.
+func (g *TGMGenerator) Next() int {
+	var p int
+	if g.first {
+		g.first = false
+		p = tgmFirstPieces[g.rng.IntN(len(tgmFirstPieces))]
+	} else {
+		for range g.rolls {
+			p = g.rng.IntN(pieceCount)
+			if !g.inHistory(p) {
+				break
+			}
+		}
+	}
+	copy(g.history[:], g.history[1:])
+	g.history[len(g.history)-1] = p
+	return p
+}
+
+// inHistory reports whether p is among the last four pieces.
+func (g *TGMGenerator) inHistory(p int) bool {
+	for _, h := range g.history {
+		if h == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Peek returns the next n piece indices without advancing the sequence.
+func (g *TGMGenerator) Peek(n int) []int { return peek(g, n) }
+
+// Clone returns an independent generator continuing the same sequence.
+func (g *TGMGenerator) Clone() Randomizer {
+	c := *g
+	c.src = clonePCG(g.src)
+	c.rng = rand.New(c.src)
+	return &c
+}
+
+// tgmState is the serialized form of a TGMGenerator.
+type tgmState struct {
+	Rolls   int    `json:"rolls"`
+	History [4]int `json:"history"`
+	First   bool   `json:"first"`
+	PCG     []byte `json:"pcg"`
+}
+
+// MarshalBinary encodes the roll count, history and PCG state.
+func (g *TGMGenerator) MarshalBinary() ([]byte, error) {
+	pcg, err := g.src.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tgmState{Rolls: g.rolls, History: g.history, First: g.first, PCG: pcg})
+}
+
+// UnmarshalBinary restores a state produced by MarshalBinary.
+func (g *TGMGenerator) UnmarshalBinary(data []byte) error {
+	var st tgmState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return err
+	}
+	if st.Rolls < 1 {
+		return fmt.Errorf("tgm randomizer state: invalid roll count %d", st.Rolls)
+	}
+	if err := checkPieces(st.History[:]...); err != nil {
+		return fmt.Errorf("tgm randomizer state: %w", err)
+	}
+	src := &rand.PCG{}
+	if err := src.UnmarshalBinary(st.PCG); err != nil {
+		return err
+	}
+	g.rolls, g.history, g.first = st.Rolls, st.History, st.First
+	g.src, g.rng = src, rand.New(src)
+	return nil
+}